@@ -12,27 +12,37 @@ func IsAscii[T byte | rune](c T) bool {
 }
 
 func IsLower[T byte | rune](c T) bool {
-	return 'a' <= c && c <= 'z'
+	return Is(c, ClassLower)
 }
 
 func IsUpper[T byte | rune](c T) bool {
-	return 'A' <= c && c <= 'Z'
+	return Is(c, ClassUpper)
 }
 
 func IsAlpha[T byte | rune](c T) bool {
-	return IsLower(c) || IsUpper(c)
+	return Is(c, ClassAlpha)
 }
 
 func IsDigit[T byte | rune](c T) bool {
-	return '0' <= c && c <= '9'
+	return Is(c, ClassDigit)
 }
 
 func IsAlphaNum[T byte | rune](c T) bool {
-	return IsAlpha(c) || IsDigit(c)
+	return Is(c, ClassAlnum)
 }
 
 func IsXDigit[T byte | rune](c T) bool {
-	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+	return Is(c, ClassXDigit)
+}
+
+// IsOctDigit 判断是否为八进制数字 0-7
+func IsOctDigit[T byte | rune](c T) bool {
+	return Is(c, ClassOctDigit)
+}
+
+// IsBinDigit 判断是否为二进制数字 0-1
+func IsBinDigit[T byte | rune](c T) bool {
+	return Is(c, ClassBinDigit)
 }
 
 func ParseXDigit[T byte | rune](c T) (byte, bool) {
@@ -47,12 +57,54 @@ func ParseXDigit[T byte | rune](c T) (byte, bool) {
 	}
 }
 
+// ParseDigit 解析指定进制下的单个数字，base 取值为 2~36(其他取值均返回 false)，字母不区分大小写
+func ParseDigit[T byte | rune](c T, base int) (byte, bool) {
+	if base < 2 || base > 36 {
+		return 0, false
+	}
+
+	var d byte
+	if c >= '0' && c <= '9' {
+		d = byte(c - '0')
+	} else if c >= 'A' && c <= 'Z' {
+		d = byte(c - 'A' + 10)
+	} else if c >= 'a' && c <= 'z' {
+		d = byte(c - 'a' + 10)
+	} else {
+		return 0, false
+	}
+	if int(d) >= base {
+		return 0, false
+	}
+	return d, true
+}
+
 func IsControl[T byte | rune](c T) bool {
-	return (0 <= c && c <= 0x1f) || c == 0x7f
+	return Is(c, ClassCntrl)
 }
 
 func IsSpace[T byte | rune](c T) bool {
-	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+	return Is(c, ClassSpace)
+}
+
+// IsBlank 判断是否为空格或制表符，对应 C 语言 isblank()
+func IsBlank[T byte | rune](c T) bool {
+	return Is(c, ClassBlank)
+}
+
+// IsPunct 判断是否为标点符号(除空格、字母、数字外的可打印字符)，对应 C 语言 ispunct()
+func IsPunct[T byte | rune](c T) bool {
+	return Is(c, ClassPunct)
+}
+
+// IsPrint 判断是否为可打印字符(含空格)，对应 C 语言 isprint()
+func IsPrint[T byte | rune](c T) bool {
+	return Is(c, ClassPrint)
+}
+
+// IsGraph 判断是否为除空格外的可打印字符，对应 C 语言 isgraph()
+func IsGraph[T byte | rune](c T) bool {
+	return Is(c, ClassGraph)
 }
 
 func ToLower[T byte | rune](c T) T {
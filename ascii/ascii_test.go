@@ -0,0 +1,92 @@
+package ascii
+
+import "testing"
+
+func TestParseDigit(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      byte
+		base   int
+		want   byte
+		wantOk bool
+	}{
+		{"", '0', 2, 0, true},
+		{"", '1', 2, 1, true},
+		{"", '2', 2, 0, false},
+		{"", '7', 8, 7, true},
+		{"", '8', 8, 0, false},
+		{"", '9', 10, 9, true},
+		{"", 'a', 10, 0, false},
+		{"", 'f', 16, 15, true},
+		{"", 'F', 16, 15, true},
+		{"", 'g', 16, 0, false},
+		{"", 'z', 36, 35, true},
+		{"", 'Z', 36, 35, true},
+		{"", '_', 36, 0, false},
+		{"", 0xff, 36, 0, false},
+		{"", '0', 1, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseDigit(tt.c, tt.base)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ParseDigit(%q, %d) = %v, %v, want %v, %v", tt.c, tt.base, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+
+	// 与 ParseXDigit 结果一致
+	for c := 0; c < 256; c++ {
+		got, ok := ParseDigit(byte(c), 16)
+		want, wantOk := ParseXDigit(byte(c))
+		if got != want || ok != wantOk {
+			t.Errorf("ParseDigit(%q, 16) = %v, %v, want %v, %v", c, got, ok, want, wantOk)
+		}
+	}
+}
+
+// TestClass 所有单字符判断函数与按定义直接比较的结果一致
+func TestClass(t *testing.T) {
+	in := func(c rune, chars string) bool {
+		for _, x := range chars {
+			if c == x {
+				return true
+			}
+		}
+		return false
+	}
+	tests := []struct {
+		name string
+		fn   func(rune) bool
+		bfn  func(byte) bool
+		ref  func(rune) bool
+	}{
+		{"IsLower", IsLower[rune], IsLower[byte], func(c rune) bool { return 'a' <= c && c <= 'z' }},
+		{"IsUpper", IsUpper[rune], IsUpper[byte], func(c rune) bool { return 'A' <= c && c <= 'Z' }},
+		{"IsAlpha", IsAlpha[rune], IsAlpha[byte], func(c rune) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }},
+		{"IsDigit", IsDigit[rune], IsDigit[byte], func(c rune) bool { return '0' <= c && c <= '9' }},
+		{"IsAlphaNum", IsAlphaNum[rune], IsAlphaNum[byte], func(c rune) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' }},
+		{"IsXDigit", IsXDigit[rune], IsXDigit[byte], func(c rune) bool { return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F' }},
+		{"IsOctDigit", IsOctDigit[rune], IsOctDigit[byte], func(c rune) bool { return '0' <= c && c <= '7' }},
+		{"IsBinDigit", IsBinDigit[rune], IsBinDigit[byte], func(c rune) bool { return c == '0' || c == '1' }},
+		{"IsControl", IsControl[rune], IsControl[byte], func(c rune) bool { return 0 <= c && c <= 0x1f || c == 0x7f }},
+		{"IsSpace", IsSpace[rune], IsSpace[byte], func(c rune) bool { return in(c, " \t\n\v\f\r") }},
+		{"IsBlank", IsBlank[rune], IsBlank[byte], func(c rune) bool { return in(c, " \t") }},
+		{"IsPrint", IsPrint[rune], IsPrint[byte], func(c rune) bool { return 0x20 <= c && c <= 0x7e }},
+		{"IsGraph", IsGraph[rune], IsGraph[byte], func(c rune) bool { return 0x21 <= c && c <= 0x7e }},
+		{"IsPunct", IsPunct[rune], IsPunct[byte], func(c rune) bool { return in(c, "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~") }},
+	}
+	for _, tt := range tests {
+		for c := rune(-1); c <= 0x200; c++ {
+			if got, want := tt.fn(c), tt.ref(c); got != want {
+				t.Errorf("%s(%q) = %v, want %v", tt.name, c, got, want)
+			}
+		}
+		// byte 与 rune 版本结果一致
+		for c := 0; c < 256; c++ {
+			if tt.bfn(byte(c)) != tt.fn(rune(c)) {
+				t.Errorf("%s(%q) byte/rune mismatch", tt.name, c)
+			}
+		}
+	}
+}
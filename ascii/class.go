@@ -0,0 +1,168 @@
+package ascii
+
+// Class 字符类别位掩码，一个字符可以同时属于多个类别
+type Class uint16
+
+const (
+	ClassUpper    Class = 1 << iota // 大写字母 A-Z
+	ClassLower                      // 小写字母 a-z
+	ClassDigit                      // 十进制数字 0-9
+	ClassXDigit                     // 十六进制数字 0-9a-fA-F
+	ClassOctDigit                   // 八进制数字 0-7
+	ClassBinDigit                   // 二进制数字 0-1
+	ClassSpace                      // 空白字符 " \t\n\v\f\r"
+	ClassBlank                      // 空格及制表符 " \t"
+	ClassPunct                      // 标点符号，除空格、字母、数字外的可打印字符
+	ClassCntrl                      // 控制字符 0x00-0x1f、0x7f
+	ClassPrint                      // 可打印字符 0x20-0x7e
+	ClassGraph                      // 除空格外的可打印字符 0x21-0x7e
+
+	ClassAlpha = ClassUpper | ClassLower // 字母
+	ClassAlnum = ClassAlpha | ClassDigit // 字母或数字
+)
+
+// ClassTable 每个字节所属的字符类别，与 C 语言 "C" locale 下的 ctype.h 一致，非 ASCII 字符不属于任何类别
+// 以字面量定义，不需要在 init() 中构建；ascii、xstrings 等包中的字符类别判断均以此表为准
+var ClassTable = [256]Class{
+	0x00: ClassCntrl,
+	0x01: ClassCntrl,
+	0x02: ClassCntrl,
+	0x03: ClassCntrl,
+	0x04: ClassCntrl,
+	0x05: ClassCntrl,
+	0x06: ClassCntrl,
+	0x07: ClassCntrl,
+	0x08: ClassCntrl,
+	'\t': ClassSpace | ClassBlank | ClassCntrl,
+	'\n': ClassSpace | ClassCntrl,
+	'\v': ClassSpace | ClassCntrl,
+	'\f': ClassSpace | ClassCntrl,
+	'\r': ClassSpace | ClassCntrl,
+	0x0e: ClassCntrl,
+	0x0f: ClassCntrl,
+	0x10: ClassCntrl,
+	0x11: ClassCntrl,
+	0x12: ClassCntrl,
+	0x13: ClassCntrl,
+	0x14: ClassCntrl,
+	0x15: ClassCntrl,
+	0x16: ClassCntrl,
+	0x17: ClassCntrl,
+	0x18: ClassCntrl,
+	0x19: ClassCntrl,
+	0x1a: ClassCntrl,
+	0x1b: ClassCntrl,
+	0x1c: ClassCntrl,
+	0x1d: ClassCntrl,
+	0x1e: ClassCntrl,
+	0x1f: ClassCntrl,
+	' ':  ClassSpace | ClassBlank | ClassPrint,
+	'!':  ClassPunct | ClassPrint | ClassGraph,
+	'"':  ClassPunct | ClassPrint | ClassGraph,
+	'#':  ClassPunct | ClassPrint | ClassGraph,
+	'$':  ClassPunct | ClassPrint | ClassGraph,
+	'%':  ClassPunct | ClassPrint | ClassGraph,
+	'&':  ClassPunct | ClassPrint | ClassGraph,
+	'\'': ClassPunct | ClassPrint | ClassGraph,
+	'(':  ClassPunct | ClassPrint | ClassGraph,
+	')':  ClassPunct | ClassPrint | ClassGraph,
+	'*':  ClassPunct | ClassPrint | ClassGraph,
+	'+':  ClassPunct | ClassPrint | ClassGraph,
+	',':  ClassPunct | ClassPrint | ClassGraph,
+	'-':  ClassPunct | ClassPrint | ClassGraph,
+	'.':  ClassPunct | ClassPrint | ClassGraph,
+	'/':  ClassPunct | ClassPrint | ClassGraph,
+	'0':  ClassDigit | ClassXDigit | ClassOctDigit | ClassBinDigit | ClassPrint | ClassGraph,
+	'1':  ClassDigit | ClassXDigit | ClassOctDigit | ClassBinDigit | ClassPrint | ClassGraph,
+	'2':  ClassDigit | ClassXDigit | ClassOctDigit | ClassPrint | ClassGraph,
+	'3':  ClassDigit | ClassXDigit | ClassOctDigit | ClassPrint | ClassGraph,
+	'4':  ClassDigit | ClassXDigit | ClassOctDigit | ClassPrint | ClassGraph,
+	'5':  ClassDigit | ClassXDigit | ClassOctDigit | ClassPrint | ClassGraph,
+	'6':  ClassDigit | ClassXDigit | ClassOctDigit | ClassPrint | ClassGraph,
+	'7':  ClassDigit | ClassXDigit | ClassOctDigit | ClassPrint | ClassGraph,
+	'8':  ClassDigit | ClassXDigit | ClassPrint | ClassGraph,
+	'9':  ClassDigit | ClassXDigit | ClassPrint | ClassGraph,
+	':':  ClassPunct | ClassPrint | ClassGraph,
+	';':  ClassPunct | ClassPrint | ClassGraph,
+	'<':  ClassPunct | ClassPrint | ClassGraph,
+	'=':  ClassPunct | ClassPrint | ClassGraph,
+	'>':  ClassPunct | ClassPrint | ClassGraph,
+	'?':  ClassPunct | ClassPrint | ClassGraph,
+	'@':  ClassPunct | ClassPrint | ClassGraph,
+	'A':  ClassUpper | ClassXDigit | ClassPrint | ClassGraph,
+	'B':  ClassUpper | ClassXDigit | ClassPrint | ClassGraph,
+	'C':  ClassUpper | ClassXDigit | ClassPrint | ClassGraph,
+	'D':  ClassUpper | ClassXDigit | ClassPrint | ClassGraph,
+	'E':  ClassUpper | ClassXDigit | ClassPrint | ClassGraph,
+	'F':  ClassUpper | ClassXDigit | ClassPrint | ClassGraph,
+	'G':  ClassUpper | ClassPrint | ClassGraph,
+	'H':  ClassUpper | ClassPrint | ClassGraph,
+	'I':  ClassUpper | ClassPrint | ClassGraph,
+	'J':  ClassUpper | ClassPrint | ClassGraph,
+	'K':  ClassUpper | ClassPrint | ClassGraph,
+	'L':  ClassUpper | ClassPrint | ClassGraph,
+	'M':  ClassUpper | ClassPrint | ClassGraph,
+	'N':  ClassUpper | ClassPrint | ClassGraph,
+	'O':  ClassUpper | ClassPrint | ClassGraph,
+	'P':  ClassUpper | ClassPrint | ClassGraph,
+	'Q':  ClassUpper | ClassPrint | ClassGraph,
+	'R':  ClassUpper | ClassPrint | ClassGraph,
+	'S':  ClassUpper | ClassPrint | ClassGraph,
+	'T':  ClassUpper | ClassPrint | ClassGraph,
+	'U':  ClassUpper | ClassPrint | ClassGraph,
+	'V':  ClassUpper | ClassPrint | ClassGraph,
+	'W':  ClassUpper | ClassPrint | ClassGraph,
+	'X':  ClassUpper | ClassPrint | ClassGraph,
+	'Y':  ClassUpper | ClassPrint | ClassGraph,
+	'Z':  ClassUpper | ClassPrint | ClassGraph,
+	'[':  ClassPunct | ClassPrint | ClassGraph,
+	'\\': ClassPunct | ClassPrint | ClassGraph,
+	']':  ClassPunct | ClassPrint | ClassGraph,
+	'^':  ClassPunct | ClassPrint | ClassGraph,
+	'_':  ClassPunct | ClassPrint | ClassGraph,
+	'`':  ClassPunct | ClassPrint | ClassGraph,
+	'a':  ClassLower | ClassXDigit | ClassPrint | ClassGraph,
+	'b':  ClassLower | ClassXDigit | ClassPrint | ClassGraph,
+	'c':  ClassLower | ClassXDigit | ClassPrint | ClassGraph,
+	'd':  ClassLower | ClassXDigit | ClassPrint | ClassGraph,
+	'e':  ClassLower | ClassXDigit | ClassPrint | ClassGraph,
+	'f':  ClassLower | ClassXDigit | ClassPrint | ClassGraph,
+	'g':  ClassLower | ClassPrint | ClassGraph,
+	'h':  ClassLower | ClassPrint | ClassGraph,
+	'i':  ClassLower | ClassPrint | ClassGraph,
+	'j':  ClassLower | ClassPrint | ClassGraph,
+	'k':  ClassLower | ClassPrint | ClassGraph,
+	'l':  ClassLower | ClassPrint | ClassGraph,
+	'm':  ClassLower | ClassPrint | ClassGraph,
+	'n':  ClassLower | ClassPrint | ClassGraph,
+	'o':  ClassLower | ClassPrint | ClassGraph,
+	'p':  ClassLower | ClassPrint | ClassGraph,
+	'q':  ClassLower | ClassPrint | ClassGraph,
+	'r':  ClassLower | ClassPrint | ClassGraph,
+	's':  ClassLower | ClassPrint | ClassGraph,
+	't':  ClassLower | ClassPrint | ClassGraph,
+	'u':  ClassLower | ClassPrint | ClassGraph,
+	'v':  ClassLower | ClassPrint | ClassGraph,
+	'w':  ClassLower | ClassPrint | ClassGraph,
+	'x':  ClassLower | ClassPrint | ClassGraph,
+	'y':  ClassLower | ClassPrint | ClassGraph,
+	'z':  ClassLower | ClassPrint | ClassGraph,
+	'{':  ClassPunct | ClassPrint | ClassGraph,
+	'|':  ClassPunct | ClassPrint | ClassGraph,
+	'}':  ClassPunct | ClassPrint | ClassGraph,
+	'~':  ClassPunct | ClassPrint | ClassGraph,
+	0x7f: ClassCntrl,
+}
+
+// classOf 返回字符所属的类别，超出字节范围的字符不属于任何类别
+func classOf[T byte | rune](c T) Class {
+	if c < 0 || c > 0xff {
+		return 0
+	}
+	return ClassTable[byte(c)]
+}
+
+// Is 判断字符是否属于类别 mask 之一
+func Is[T byte | rune](c T, mask Class) bool {
+	return classOf(c)&mask != 0
+}
@@ -0,0 +1,56 @@
+package ascii
+
+// 本文件内是字符串级别的字符类别判断函数，与 PHP 的 ctype_* 函数一致: 所有字节均属于对应类别时返回 true，空字符串返回 false
+
+// isAll 判断 s 非空且所有字节均属于类别 mask 之一
+func isAll[S ~string | ~[]byte](s S, mask Class) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if ClassTable[s[i]]&mask == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAllDigit 判断是否全部为十进制数字，对应 PHP 函数 ctype_digit()
+func IsAllDigit[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassDigit)
+}
+
+// IsAllAlpha 判断是否全部为字母，对应 PHP 函数 ctype_alpha()
+func IsAllAlpha[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassAlpha)
+}
+
+// IsAllAlnum 判断是否全部为字母或数字，对应 PHP 函数 ctype_alnum()
+func IsAllAlnum[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassAlnum)
+}
+
+// IsAllSpace 判断是否全部为空白字符(" \t\n\v\f\r")，对应 PHP 函数 ctype_space()
+func IsAllSpace[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassSpace)
+}
+
+// IsAllXDigit 判断是否全部为十六进制数字，对应 PHP 函数 ctype_xdigit()
+func IsAllXDigit[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassXDigit)
+}
+
+// IsAllPunct 判断是否全部为标点符号(除空格、字母、数字外的可打印字符)，对应 PHP 函数 ctype_punct()
+func IsAllPunct[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassPunct)
+}
+
+// IsAllUpper 判断是否全部为大写字母，对应 PHP 函数 ctype_upper()
+func IsAllUpper[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassUpper)
+}
+
+// IsAllLower 判断是否全部为小写字母，对应 PHP 函数 ctype_lower()
+func IsAllLower[S ~string | ~[]byte](s S) bool {
+	return isAll(s, ClassLower)
+}
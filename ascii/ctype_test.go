@@ -0,0 +1,51 @@
+package ascii
+
+import "testing"
+
+func TestIsAll(t *testing.T) {
+	type predicates struct {
+		digit, alpha, alnum, space, xdigit, punct, upper, lower bool
+	}
+	tests := []struct {
+		s    string
+		want predicates
+	}{
+		{"", predicates{}},
+		{"123", predicates{digit: true, alnum: true, xdigit: true}},
+		{"abc", predicates{alpha: true, alnum: true, xdigit: true, lower: true}},
+		{"ABC", predicates{alpha: true, alnum: true, xdigit: true, upper: true}},
+		{"AbC", predicates{alpha: true, alnum: true, xdigit: true}},
+		{"xyz", predicates{alpha: true, alnum: true, lower: true}},
+		{"abc123", predicates{alnum: true, xdigit: true}},
+		{" \t\n\v\f\r", predicates{space: true}},
+		{"!@#$%^&*()_+-=[]{}|;':\",./<>?`~\\", predicates{punct: true}},
+		{"1.5", predicates{}},
+		{"-1", predicates{}},
+		{"12 ", predicates{}},
+		{"\x00", predicates{}},
+		{"é", predicates{}},
+		{"abc\x80", predicates{}},
+	}
+	for _, tt := range tests {
+		got := predicates{
+			digit:  IsAllDigit(tt.s),
+			alpha:  IsAllAlpha(tt.s),
+			alnum:  IsAllAlnum(tt.s),
+			space:  IsAllSpace(tt.s),
+			xdigit: IsAllXDigit(tt.s),
+			punct:  IsAllPunct(tt.s),
+			upper:  IsAllUpper(tt.s),
+			lower:  IsAllLower([]byte(tt.s)),
+		}
+		if got != tt.want {
+			t.Errorf("IsAll*(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkIsAllDigit(b *testing.B) {
+	s := "12345678901234567890123456789012"
+	for b.Loop() {
+		IsAllDigit(s)
+	}
+}
@@ -0,0 +1,158 @@
+package ascii
+
+// 本文件内是按字符类别或字节集合查找下标的函数，多用于词法分析中跳过空白或标识符等连续字节
+// 字符类别及 ASCII 字节集合以 SWAR 方式每次判断 8 个字节，其余情况逐字节查表；所有函数均按字节处理，非 ASCII 字节不属于任何字符类别
+
+import (
+	"github.com/heyuuu/gophp-utils/internal/swar"
+	"math/bits"
+)
+
+// IndexNonSpace 返回首个非空白字节(IsSpace 为 false)的下标，不存在时返回 -1
+func IndexNonSpace[S ~string | ~[]byte](s S) int {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if x := swarSpaceMask(swar.Load64(s, i)) ^ swar.High; x != 0 {
+			return i + bits.TrailingZeros64(x)/8
+		}
+	}
+	for ; i < len(s); i++ {
+		if !IsSpace(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexNonSpace 返回最后一个非空白字节的下标，不存在时返回 -1
+func LastIndexNonSpace[S ~string | ~[]byte](s S) int {
+	i := len(s)
+	for ; i >= 8; i -= 8 {
+		if x := swarSpaceMask(swar.Load64(s, i-8)) ^ swar.High; x != 0 {
+			return i - 1 - bits.LeadingZeros64(x)/8
+		}
+	}
+	for i--; i >= 0; i-- {
+		if !IsSpace(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexClass 返回首个属于类别 mask 之一的字节下标，不存在时返回 -1
+func IndexClass[S ~string | ~[]byte](s S, mask Class) int {
+	return indexClass(s, mask, true)
+}
+
+// IndexNotClass 返回首个不属于类别 mask 中任一类别的字节下标，不存在时返回 -1
+func IndexNotClass[S ~string | ~[]byte](s S, mask Class) int {
+	return indexClass(s, mask, false)
+}
+
+// LastIndexClass 返回最后一个属于类别 mask 之一的字节下标，不存在时返回 -1
+func LastIndexClass[S ~string | ~[]byte](s S, mask Class) int {
+	return lastIndexClass(s, mask, true)
+}
+
+// LastIndexNotClass 返回最后一个不属于类别 mask 中任一类别的字节下标，不存在时返回 -1
+func LastIndexNotClass[S ~string | ~[]byte](s S, mask Class) int {
+	return lastIndexClass(s, mask, false)
+}
+
+// IndexIn 返回首个属于 set 的字节下标，不存在时返回 -1
+func IndexIn[S ~string | ~[]byte](s S, set Set) int {
+	return indexSet(s, set, true)
+}
+
+// IndexNotIn 返回首个不属于 set 的字节下标，不存在时返回 -1
+// set 只含 ASCII 字节(或包含全部非 ASCII 字节)且由不超过 8 个连续区间组成时可每次判断 8 个字节
+func IndexNotIn[S ~string | ~[]byte](s S, set Set) int {
+	return indexSet(s, set, false)
+}
+
+// LastIndexIn 返回最后一个属于 set 的字节下标，不存在时返回 -1
+func LastIndexIn[S ~string | ~[]byte](s S, set Set) int {
+	return lastIndexSet(s, set, true)
+}
+
+// LastIndexNotIn 返回最后一个不属于 set 的字节下标，不存在时返回 -1
+func LastIndexNotIn[S ~string | ~[]byte](s S, set Set) int {
+	return lastIndexSet(s, set, false)
+}
+
+func indexClass[S ~string | ~[]byte](s S, mask Class, want bool) int {
+	var m swarMatcher // 不足 8 字节时无需构建
+	if len(s) >= 8 {
+		m = classMatcher(mask)
+	}
+	return index(s, &m, want, func(c byte) bool { return ClassTable[c]&mask != 0 })
+}
+
+func lastIndexClass[S ~string | ~[]byte](s S, mask Class, want bool) int {
+	var m swarMatcher
+	if len(s) >= 8 {
+		m = classMatcher(mask)
+	}
+	return lastIndex(s, &m, want, func(c byte) bool { return ClassTable[c]&mask != 0 })
+}
+
+func indexSet[S ~string | ~[]byte](s S, set Set, want bool) int {
+	var m swarMatcher
+	if len(s) >= 8 {
+		m = setMatcher(set)
+	}
+	return index(s, &m, want, set.Contains)
+}
+
+func lastIndexSet[S ~string | ~[]byte](s S, set Set, want bool) int {
+	var m swarMatcher
+	if len(s) >= 8 {
+		m = setMatcher(set)
+	}
+	return lastIndex(s, &m, want, set.Contains)
+}
+
+// index 返回首个 contains(c) == want 的字节下标，m 可用时以 8 字节为单位判断
+func index[S ~string | ~[]byte](s S, m *swarMatcher, want bool, contains func(byte) bool) int {
+	i := 0
+	if m.ok {
+		var flip uint64
+		if !want {
+			flip = swar.High
+		}
+		for ; i+8 <= len(s); i += 8 {
+			if x := m.mask(swar.Load64(s, i)) ^ flip; x != 0 {
+				return i + bits.TrailingZeros64(x)/8
+			}
+		}
+	}
+	for ; i < len(s); i++ {
+		if contains(s[i]) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastIndex 返回最后一个 contains(c) == want 的字节下标，m 可用时以 8 字节为单位判断
+func lastIndex[S ~string | ~[]byte](s S, m *swarMatcher, want bool, contains func(byte) bool) int {
+	i := len(s)
+	if m.ok {
+		var flip uint64
+		if !want {
+			flip = swar.High
+		}
+		for ; i >= 8; i -= 8 {
+			if x := m.mask(swar.Load64(s, i-8)) ^ flip; x != 0 {
+				return i - 1 - bits.LeadingZeros64(x)/8
+			}
+		}
+	}
+	for i--; i >= 0; i-- {
+		if contains(s[i]) == want {
+			return i
+		}
+	}
+	return -1
+}
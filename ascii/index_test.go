@@ -0,0 +1,230 @@
+package ascii
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestIndexNonSpace(t *testing.T) {
+	tests := []struct {
+		s     string
+		want  int
+		wantL int
+	}{
+		{"", -1, -1},
+		{" \t\n\v\f\r", -1, -1},
+		{"abc", 0, 2},
+		{"  abc  ", 2, 4},
+		{"\x00 ", 0, 0},
+		{"   ", 1, 2},
+		{strings.Repeat(" ", 20) + "x" + strings.Repeat("\t", 20), 20, 20},
+		{strings.Repeat("\r\n", 16), -1, -1},
+	}
+	for _, tt := range tests {
+		if got := IndexNonSpace(tt.s); got != tt.want {
+			t.Errorf("IndexNonSpace(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+		if got := LastIndexNonSpace([]byte(tt.s)); got != tt.wantL {
+			t.Errorf("LastIndexNonSpace(%q) = %d, want %d", tt.s, got, tt.wantL)
+		}
+	}
+}
+
+func TestIndexClass(t *testing.T) {
+	tests := []struct {
+		s        string
+		mask     Class
+		want     int
+		wantNot  int
+		wantL    int
+		wantLNot int
+	}{
+		{"", ClassDigit, -1, -1, -1, -1},
+		{"abc123", ClassDigit, 3, 0, 5, 2},
+		{"user_name2 = 1", ClassAlnum, 0, 4, 13, 12},
+		{"0123456789abcdef", ClassXDigit, 0, -1, 15, -1},
+		{"变量名$name", ClassAlpha, 10, 0, 13, 9},
+		{"\x7f\x00\x1f", ClassCntrl, 0, -1, 2, -1},
+		{"!/:@[`{~ 09AZaz", ClassPunct, 0, 8, 7, 14},
+	}
+	for _, tt := range tests {
+		if got := IndexClass(tt.s, tt.mask); got != tt.want {
+			t.Errorf("IndexClass(%q, %v) = %d, want %d", tt.s, tt.mask, got, tt.want)
+		}
+		if got := IndexNotClass(tt.s, tt.mask); got != tt.wantNot {
+			t.Errorf("IndexNotClass(%q, %v) = %d, want %d", tt.s, tt.mask, got, tt.wantNot)
+		}
+		if got := LastIndexClass(tt.s, tt.mask); got != tt.wantL {
+			t.Errorf("LastIndexClass(%q, %v) = %d, want %d", tt.s, tt.mask, got, tt.wantL)
+		}
+		if got := LastIndexNotClass(tt.s, tt.mask); got != tt.wantLNot {
+			t.Errorf("LastIndexNotClass(%q, %v) = %d, want %d", tt.s, tt.mask, got, tt.wantLNot)
+		}
+	}
+}
+
+func TestIndexIn(t *testing.T) {
+	ident := NewSet("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_")
+	tests := []struct {
+		s        string
+		set      Set
+		want     int
+		wantNot  int
+		wantL    int
+		wantLNot int
+	}{
+		{"", ident, -1, -1, -1, -1},
+		{"foo_bar + baz", ident, 0, 7, 12, 9},
+		{"$long_variable_name;", ident, 1, 0, 18, 19},
+		{"héllo", ident, 0, 1, 5, 2},
+		{"héllo", ident.Union(AsciiSet.Complement()), 0, -1, 5, -1},
+		{"a\x80b\xffc", NewSet("\x80\xff"), 1, 0, 3, 4},
+		{"a\x00b", Set{}, -1, 0, -1, 2},
+	}
+	for _, tt := range tests {
+		if got := IndexIn(tt.s, tt.set); got != tt.want {
+			t.Errorf("IndexIn(%q, %q) = %d, want %d", tt.s, tt.set, got, tt.want)
+		}
+		if got := IndexNotIn(tt.s, tt.set); got != tt.wantNot {
+			t.Errorf("IndexNotIn(%q, %q) = %d, want %d", tt.s, tt.set, got, tt.wantNot)
+		}
+		if got := LastIndexIn(tt.s, tt.set); got != tt.wantL {
+			t.Errorf("LastIndexIn(%q, %q) = %d, want %d", tt.s, tt.set, got, tt.wantL)
+		}
+		if got := LastIndexNotIn(tt.s, tt.set); got != tt.wantLNot {
+			t.Errorf("LastIndexNotIn(%q, %q) = %d, want %d", tt.s, tt.set, got, tt.wantLNot)
+		}
+	}
+}
+
+// TestClassRanges classRanges 与 ClassTable 一致
+func TestClassRanges(t *testing.T) {
+	for bit := Class(1); bit <= ClassGraph; bit <<= 1 {
+		var set Set
+		for _, r := range classRanges {
+			if r.class == bit {
+				set.AddRange(r.lo, r.hi)
+			}
+		}
+		if set != ClassSet(bit) {
+			t.Errorf("classRanges of %#x = %q, want %q", bit, set, ClassSet(bit))
+		}
+	}
+}
+
+// 以下为逐字节判断的参考实现，用于校验 SWAR 实现的正确性
+
+func naiveIndex(s string, in func(byte) bool, want bool) int {
+	for i := 0; i < len(s); i++ {
+		if in(s[i]) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+func naiveLastIndex(s string, in func(byte) bool, want bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if in(s[i]) == want {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkIndexEquivalence 校验单个字符串在各函数上与参考实现结果一致
+func checkIndexEquivalence(t *testing.T, s string, mask Class, set Set) {
+	t.Helper()
+	inClass := func(c byte) bool { return ClassTable[c]&mask != 0 }
+	check := func(name string, got, want int) {
+		t.Helper()
+		if got != want {
+			t.Fatalf("%s(%q, %#x, %q) = %d, want %d", name, s, mask, set, got, want)
+		}
+	}
+	check("IndexNonSpace", IndexNonSpace(s), naiveIndex(s, IsSpace[byte], false))
+	check("LastIndexNonSpace", LastIndexNonSpace(s), naiveLastIndex(s, IsSpace[byte], false))
+	check("IndexClass", IndexClass(s, mask), naiveIndex(s, inClass, true))
+	check("IndexNotClass", IndexNotClass(s, mask), naiveIndex(s, inClass, false))
+	check("LastIndexClass", LastIndexClass(s, mask), naiveLastIndex(s, inClass, true))
+	check("LastIndexNotClass", LastIndexNotClass(s, mask), naiveLastIndex(s, inClass, false))
+	check("IndexIn", IndexIn(s, set), naiveIndex(s, set.Contains, true))
+	check("IndexNotIn", IndexNotIn(s, set), naiveIndex(s, set.Contains, false))
+	check("LastIndexIn", LastIndexIn(s, set), naiveLastIndex(s, set.Contains, true))
+	check("LastIndexNotIn", LastIndexNotIn(s, set), naiveLastIndex(s, set.Contains, false))
+}
+
+// TestIndexExhaustive 对每个字节值、每个长度(覆盖整 word 与尾部)、每个位置进行校验
+func TestIndexExhaustive(t *testing.T) {
+	masks := []Class{ClassSpace, ClassAlnum, ClassPunct, ClassCntrl, ClassXDigit | ClassBlank, ClassPrint}
+	sets := []Set{
+		SpaceSet,
+		NewSet("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_"),
+		NewSet("?@AB"),
+		NewSet("\x00\x7f").Union(AsciiSet.Complement()),
+		NewSet("acegikmoqsuwy"),             // 区间数超出上限，逐字节查表
+		NewSet("xyz").Union(NewSet("\xff")), // 非 ASCII 部分不完整，逐字节查表
+	}
+	fillers := []byte{' ', 'a', '_', '\x00', 0x80, 0xff}
+	for n := 1; n <= 20; n++ {
+		for c := 0; c < 256; c++ {
+			for _, filler := range fillers {
+				buf := []byte(strings.Repeat(string([]byte{filler}), n))
+				for pos := 0; pos < n; pos++ {
+					buf[pos] = byte(c)
+					k := (c + pos) % len(masks)
+					checkIndexEquivalence(t, string(buf), masks[k], sets[(c+n)%len(sets)])
+					buf[pos] = filler
+				}
+			}
+		}
+	}
+}
+
+func TestIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	alphabet := []byte("aZ09_ \t\r\n\v\f!/:@[`{~\x00\x1f\x7f\x80\xff")
+	for i := 0; i < 20000; i++ {
+		b := make([]byte, r.Intn(40))
+		for j := range b {
+			b[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		var set Set
+		for range r.Intn(6) {
+			lo := byte(r.Intn(0x80))
+			set.AddRange(lo, lo+byte(r.Intn(8)))
+		}
+		checkIndexEquivalence(t, string(b), Class(r.Intn(int(ClassGraph)<<1)), set)
+	}
+}
+
+var benchmarkIndexInput = strings.Repeat(" \t", 32) + strings.Repeat("user_name", 8) + ";"
+
+func Benchmark_IndexNonSpace(b *testing.B) {
+	b.Run("swar", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			IndexNonSpace(benchmarkIndexInput)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveIndex(benchmarkIndexInput, IsSpace[byte], false)
+		}
+	})
+}
+
+func Benchmark_IndexNotIn(b *testing.B) {
+	s := benchmarkIndexInput[64:]
+	ident := NewSet("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_")
+	b.Run("swar", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			IndexNotIn(s, ident)
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveIndex(s, ident.Contains, false)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package ascii
+
+// 本文件内是 PHP 标识符(词法分析器中的 LABEL)相关函数，规则与 PHP 词法分析器一致:
+// - LABEL: [a-zA-Z_\x80-\xff][a-zA-Z0-9_\x80-\xff]*
+// - 限定名: LABEL 以 '\' 分隔的序列，可带前置 '\' 表示完全限定名，e.g. "Foo\Bar"、"\Foo\Bar"
+// 0x80 及以上的字节均视为标签字符，因此 UTF-8 编码的非 ASCII 字符可以出现在标签中
+
+// IsLabelStart 判断是否可作为 PHP 标签的首字符
+func IsLabelStart[T byte | rune](c T) bool {
+	return Is(c, ClassAlpha) || c == '_' || c >= 0x80
+}
+
+// IsLabelPart 判断是否可作为 PHP 标签的非首字符
+func IsLabelPart[T byte | rune](c T) bool {
+	return Is(c, ClassAlnum) || c == '_' || c >= 0x80
+}
+
+// ScanLabel 返回 s 开头的 PHP 标签长度，不以标签开头时返回 0
+func ScanLabel[S ~string | ~[]byte](s S) int {
+	if len(s) == 0 || !IsLabelStart(s[0]) {
+		return 0
+	}
+	i := 1
+	for i < len(s) && IsLabelPart(s[i]) {
+		i++
+	}
+	return i
+}
+
+// ScanQualifiedName 返回 s 开头的 PHP 限定名长度，不以限定名开头时返回 0
+// 末尾多余的 '\' 不计入长度，e.g. "Foo\Bar\" 返回 7
+func ScanQualifiedName[S ~string | ~[]byte](s S) int {
+	i := 0
+	if len(s) > 0 && s[0] == '\\' {
+		i = 1
+	}
+	n := ScanLabel(s[i:])
+	if n == 0 {
+		return 0
+	}
+	i += n
+	for i+1 < len(s) && s[i] == '\\' {
+		n = ScanLabel(s[i+1:])
+		if n == 0 {
+			break
+		}
+		i += 1 + n
+	}
+	return i
+}
+
+// IsValidLabel 判断 s 是否为合法的 PHP 标签(可用作变量名、函数名、类名等)
+func IsValidLabel[S ~string | ~[]byte](s S) bool {
+	return len(s) > 0 && ScanLabel(s) == len(s)
+}
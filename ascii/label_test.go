@@ -0,0 +1,59 @@
+package ascii
+
+import "testing"
+
+func TestIsLabel(t *testing.T) {
+	// 参照正则 [a-zA-Z_\x80-\xff] 和 [a-zA-Z0-9_\x80-\xff] 逐字节比较
+	for c := 0; c < 256; c++ {
+		start := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_' || c >= 0x80
+		part := start || '0' <= c && c <= '9'
+		if IsLabelStart(byte(c)) != start || IsLabelStart(rune(c)) != start {
+			t.Errorf("IsLabelStart(%q) != %v", c, start)
+		}
+		if IsLabelPart(byte(c)) != part || IsLabelPart(rune(c)) != part {
+			t.Errorf("IsLabelPart(%q) != %v", c, part)
+		}
+	}
+	if IsLabelStart(rune(-1)) || IsLabelPart(rune(-1)) || !IsLabelStart('用') {
+		t.Errorf("IsLabelStart() wrong for runes outside byte range")
+	}
+}
+
+func TestScanLabel(t *testing.T) {
+	tests := []struct {
+		s         string
+		wantLabel int
+		wantName  int
+		valid     bool
+	}{
+		{"", 0, 0, false},
+		{"a", 1, 1, true},
+		{"_", 1, 1, true},
+		{"foo_bar1", 8, 8, true},
+		{"1foo", 0, 0, false},
+		{"foo bar", 3, 3, false},
+		{"foo-bar", 3, 3, false},
+		{"用户", 6, 6, true},
+		{"\x80\xff", 2, 2, true},
+		{"$foo", 0, 0, false},
+		{"Foo\\Bar", 3, 7, false},
+		{"\\Foo\\Bar", 0, 8, false},
+		{"Foo\\Bar\\", 3, 7, false},
+		{"Foo\\\\Bar", 3, 3, false},
+		{"Foo\\1Bar", 3, 3, false},
+		{"\\", 0, 0, false},
+		{"\\1", 0, 0, false},
+		{"A\\B\\C::x", 1, 5, false},
+	}
+	for _, tt := range tests {
+		if got := ScanLabel(tt.s); got != tt.wantLabel {
+			t.Errorf("ScanLabel(%q) = %d, want %d", tt.s, got, tt.wantLabel)
+		}
+		if got := ScanQualifiedName([]byte(tt.s)); got != tt.wantName {
+			t.Errorf("ScanQualifiedName(%q) = %d, want %d", tt.s, got, tt.wantName)
+		}
+		if got := IsValidLabel(tt.s); got != tt.valid {
+			t.Errorf("IsValidLabel(%q) = %v, want %v", tt.s, got, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package ascii
+
+// 本文件内是二进制字符串的可读化表示函数，用于调试输出
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuoteStyle Quote 的转义风格
+type QuoteStyle int
+
+const (
+	QuoteC     QuoteStyle = iota // C 语言字符串字面量，e.g. "a\tb\001\377"
+	QuotePHP                     // PHP 双引号字符串字面量，e.g. "a\tb\x01\xff\$"
+	QuoteCaret                   // 插入符表示法(同 cat -v)，不加引号，e.g. a^Ib^AM-^?
+	QuoteHex                     // 所有字节均以 \xHH 表示的双引号字符串字面量，e.g. "\x61\x09"
+)
+
+func (style QuoteStyle) String() string {
+	switch style {
+	case QuoteC:
+		return "QuoteC"
+	case QuotePHP:
+		return "QuotePHP"
+	case QuoteCaret:
+		return "QuoteCaret"
+	case QuoteHex:
+		return "QuoteHex"
+	default:
+		return fmt.Sprintf("QuoteStyle(%d)", int(style))
+	}
+}
+
+const hexDigits = "0123456789abcdef"
+
+// Quote 按指定风格返回 s 的可读表示，只有可打印 ASCII 字符(IsPrint)原样输出
+// - QuoteC: 常用控制字符使用 \n 等转义，其他字节使用 3 位八进制转义(避免 \x 转义吞掉后续的十六进制字符)
+// - QuotePHP: 常用控制字符使用 \n、\e 等转义，其他字节使用 \xHH 转义，'$' 转义为 \$ 避免变量插值
+// - QuoteCaret: 控制字符表示为 ^@ ~ ^_ 及 ^?，0x80 及以上的字节加 "M-" 前缀后按低 7 位表示
+// - QuoteHex: 所有字节均使用 \xHH 转义
+// style 取值不合法时 panic
+func Quote[S ~string | ~[]byte](s S, style QuoteStyle) string {
+	var buf strings.Builder
+	buf.Grow(len(s) + 2)
+	switch style {
+	case QuoteC:
+		buf.WriteByte('"')
+		for i := 0; i < len(s); i++ {
+			quoteC(&buf, s[i])
+		}
+		buf.WriteByte('"')
+	case QuotePHP:
+		buf.WriteByte('"')
+		for i := 0; i < len(s); i++ {
+			quotePHP(&buf, s[i])
+		}
+		buf.WriteByte('"')
+	case QuoteCaret:
+		for i := 0; i < len(s); i++ {
+			quoteCaret(&buf, s[i])
+		}
+	case QuoteHex:
+		buf.Grow(len(s) * 3)
+		buf.WriteByte('"')
+		for i := 0; i < len(s); i++ {
+			writeHexEscape(&buf, s[i])
+		}
+		buf.WriteByte('"')
+	default:
+		panic("ascii: invalid quote style " + style.String())
+	}
+	return buf.String()
+}
+
+func writeHexEscape(buf *strings.Builder, c byte) {
+	buf.WriteString(`\x`)
+	buf.WriteByte(hexDigits[c>>4])
+	buf.WriteByte(hexDigits[c&0xf])
+}
+
+func quoteC(buf *strings.Builder, c byte) {
+	switch c {
+	case '\a':
+		buf.WriteString(`\a`)
+	case '\b':
+		buf.WriteString(`\b`)
+	case '\t':
+		buf.WriteString(`\t`)
+	case '\n':
+		buf.WriteString(`\n`)
+	case '\v':
+		buf.WriteString(`\v`)
+	case '\f':
+		buf.WriteString(`\f`)
+	case '\r':
+		buf.WriteString(`\r`)
+	case '\\', '"':
+		buf.WriteByte('\\')
+		buf.WriteByte(c)
+	default:
+		if IsPrint(c) {
+			buf.WriteByte(c)
+			return
+		}
+		buf.WriteByte('\\')
+		buf.WriteByte('0' + c>>6)
+		buf.WriteByte('0' + c>>3&7)
+		buf.WriteByte('0' + c&7)
+	}
+}
+
+func quotePHP(buf *strings.Builder, c byte) {
+	switch c {
+	case '\t':
+		buf.WriteString(`\t`)
+	case '\n':
+		buf.WriteString(`\n`)
+	case '\v':
+		buf.WriteString(`\v`)
+	case '\f':
+		buf.WriteString(`\f`)
+	case '\r':
+		buf.WriteString(`\r`)
+	case 0x1b:
+		buf.WriteString(`\e`)
+	case '\\', '"', '$':
+		buf.WriteByte('\\')
+		buf.WriteByte(c)
+	default:
+		if IsPrint(c) {
+			buf.WriteByte(c)
+			return
+		}
+		writeHexEscape(buf, c)
+	}
+}
+
+func quoteCaret(buf *strings.Builder, c byte) {
+	if c >= 0x80 {
+		buf.WriteString("M-")
+		c &= 0x7f
+	}
+	switch {
+	case c == 0x7f:
+		buf.WriteString("^?")
+	case IsControl(c):
+		buf.WriteByte('^')
+		buf.WriteByte(c + '@')
+	default:
+		buf.WriteByte(c)
+	}
+}
+
+// Dump 返回与 hexdump -C 格式一致的多行十六进制转储
+// - 每行 16 字节: 8 位十六进制偏移、两组各 8 字节的十六进制值、以 '|' 包围的字符列
+// - 字符列中非控制字符的 ASCII 字符原样输出，其他字节输出 '.'
+// - 与上一行完全相同的连续行只输出一行 "*"
+// - 非空输入以总长度的偏移行结尾；空输入返回空字符串
+func Dump[S ~string | ~[]byte](s S) string {
+	if len(s) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	buf.Grow((len(s)/16 + 2) * 79)
+	skipping := false
+	for offset := 0; offset < len(s); offset += 16 {
+		line := s[offset:min(offset+16, len(s))]
+		if offset > 0 && len(line) == 16 && string(line) == string(s[offset-16:offset]) {
+			if !skipping {
+				buf.WriteString("*\n")
+				skipping = true
+			}
+			continue
+		}
+		skipping = false
+		dumpLine(&buf, offset, line)
+	}
+	fmt.Fprintf(&buf, "%08x\n", len(s))
+	return buf.String()
+}
+
+func dumpLine[S ~string | ~[]byte](buf *strings.Builder, offset int, line S) {
+	fmt.Fprintf(buf, "%08x ", offset)
+	for i := 0; i < 16; i++ {
+		if i == 8 {
+			buf.WriteByte(' ')
+		}
+		if i < len(line) {
+			buf.WriteByte(' ')
+			buf.WriteByte(hexDigits[line[i]>>4])
+			buf.WriteByte(hexDigits[line[i]&0xf])
+		} else {
+			buf.WriteString("   ")
+		}
+	}
+	buf.WriteString("  |")
+	for i := 0; i < len(line); i++ {
+		if c := line[i]; IsAscii(c) && !IsControl(c) {
+			buf.WriteByte(c)
+		} else {
+			buf.WriteByte('.')
+		}
+	}
+	buf.WriteString("|\n")
+}
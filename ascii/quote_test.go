@@ -0,0 +1,98 @@
+package ascii
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		s     string
+		style QuoteStyle
+		want  string
+	}{
+		{"", QuoteC, `""`},
+		{"abc", QuoteC, `"abc"`},
+		{"a\tb\nc\r\a\b\v\f", QuoteC, `"a\tb\nc\r\a\b\v\f"`},
+		{`say "hi" \o/`, QuoteC, `"say \"hi\" \\o/"`},
+		{"\x00\x01a\x1b\x7f\x80\xff", QuoteC, `"\000\001a\033\177\200\377"`},
+		{"$x", QuoteC, `"$x"`},
+		{"", QuotePHP, `""`},
+		{"a\tb\nc\r\v\f\x1b", QuotePHP, `"a\tb\nc\r\v\f\e"`},
+		{`say "hi" \o/ $x {$y}`, QuotePHP, `"say \"hi\" \\o/ \$x {\$y}"`},
+		{"\x00\x01af\x07\x7f\x80\xff", QuotePHP, `"\x00\x01af\x07\x7f\x80\xff"`},
+		{"", QuoteCaret, ``},
+		{"a\tb\n", QuoteCaret, `a^Ib^J`},
+		{"\x00\x1b\x1f\x7f", QuoteCaret, `^@^[^_^?`},
+		{"\x80\xc1\xff\xa0", QuoteCaret, `M-^@M-AM-^?M- `},
+		{`"\`, QuoteCaret, `"\`},
+		{"", QuoteHex, `""`},
+		{"a\x00\xff", QuoteHex, `"\x61\x00\xff"`},
+	}
+	for _, tt := range tests {
+		if got := Quote(tt.s, tt.style); got != tt.want {
+			t.Errorf("Quote(%q, %v) = %s, want %s", tt.s, tt.style, got, tt.want)
+		}
+		if got := Quote([]byte(tt.s), tt.style); got != tt.want {
+			t.Errorf("Quote([]byte(%q), %v) = %s, want %s", tt.s, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteInvalidStyle(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Quote() with invalid style should panic")
+		}
+	}()
+	Quote("a", QuoteStyle(100))
+}
+
+func TestDump(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"", nil},
+		{"Hello\n", []string{
+			"00000000  48 65 6c 6c 6f 0a                                 |Hello.|",
+			"00000006",
+		}},
+		{"Hello\n\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a", []string{
+			"00000000  48 65 6c 6c 6f 0a 00 01  02 03 04 05 06 07 08 09  |Hello...........|",
+			"00000010  0a                                                |.|",
+			"00000011",
+		}},
+		{"0123456789abcdef", []string{
+			"00000000  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|",
+			"00000010",
+		}},
+		{"ABCDEFGHI \x7f\x80\xff~", []string{
+			"00000000  41 42 43 44 45 46 47 48  49 20 7f 80 ff 7e        |ABCDEFGHI ...~|",
+			"0000000e",
+		}},
+		// 重复行折叠为 "*"
+		{strings.Repeat("\x00", 50), []string{
+			"00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|",
+			"*",
+			"00000030  00 00                                             |..|",
+			"00000032",
+		}},
+		{strings.Repeat("a", 16) + strings.Repeat("b", 16) + strings.Repeat("b", 16) + strings.Repeat("a", 16), []string{
+			"00000000  61 61 61 61 61 61 61 61  61 61 61 61 61 61 61 61  |aaaaaaaaaaaaaaaa|",
+			"00000010  62 62 62 62 62 62 62 62  62 62 62 62 62 62 62 62  |bbbbbbbbbbbbbbbb|",
+			"*",
+			"00000030  61 61 61 61 61 61 61 61  61 61 61 61 61 61 61 61  |aaaaaaaaaaaaaaaa|",
+			"00000040",
+		}},
+	}
+	for _, tt := range tests {
+		want := ""
+		if tt.want != nil {
+			want = strings.Join(tt.want, "\n") + "\n"
+		}
+		if got := Dump(tt.s); got != want {
+			t.Errorf("Dump(%q) =\n%s\nwant\n%s", tt.s, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package ascii
+
+import (
+	"errors"
+	"iter"
+	"math/bits"
+)
+
+// Set 256 位的字节集合，零值为空集合
+type Set [4]uint64
+
+// 与各单字符判断函数对应的预定义集合，以字面量定义，不需要在 init() 中构建
+var (
+	LowerSet    = Set{0, 0x07fffffe00000000, 0, 0}                  // IsLower
+	UpperSet    = Set{0, 0x0000000007fffffe, 0, 0}                  // IsUpper
+	AlphaSet    = Set{0, 0x07fffffe07fffffe, 0, 0}                  // IsAlpha
+	DigitSet    = Set{0x03ff000000000000, 0, 0, 0}                  // IsDigit
+	AlphaNumSet = Set{0x03ff000000000000, 0x07fffffe07fffffe, 0, 0} // IsAlphaNum
+	XDigitSet   = Set{0x03ff000000000000, 0x0000007e0000007e, 0, 0} // IsXDigit
+	OctDigitSet = Set{0x00ff000000000000, 0, 0, 0}                  // IsOctDigit
+	BinDigitSet = Set{0x0003000000000000, 0, 0, 0}                  // IsBinDigit
+	ControlSet  = Set{0x00000000ffffffff, 0x8000000000000000, 0, 0} // IsControl
+	SpaceSet    = Set{0x0000000100003e00, 0, 0, 0}                  // IsSpace
+	BlankSet    = Set{0x0000000100000200, 0, 0, 0}                  // IsBlank
+	PunctSet    = Set{0xfc00fffe00000000, 0x78000001f8000001, 0, 0} // IsPunct
+	PrintSet    = Set{0xffffffff00000000, 0x7fffffffffffffff, 0, 0} // IsPrint
+	GraphSet    = Set{0xfffffffe00000000, 0x7fffffffffffffff, 0, 0} // IsGraph
+	AsciiSet    = Set{0xffffffffffffffff, 0xffffffffffffffff, 0, 0} // IsAscii
+)
+
+var (
+	ErrRangeNoLeft     = errors.New("ascii: invalid '..'-range, no character to the left of '..'")
+	ErrRangeNoRight    = errors.New("ascii: invalid '..'-range, no character to the right of '..'")
+	ErrRangeDecreasing = errors.New("ascii: invalid '..'-range, '..'-range needs to be incrementing")
+	ErrRangeInvalid    = errors.New("ascii: invalid '..'-range")
+)
+
+// NewSet 返回由 chars 中所有字节组成的集合(不解析范围语法)
+func NewSet[S ~string | ~[]byte](chars S) Set {
+	var set Set
+	for i := 0; i < len(chars); i++ {
+		set.Add(chars[i])
+	}
+	return set
+}
+
+// ClassSet 返回属于类别 mask 之一的所有字节组成的集合
+func ClassSet(mask Class) Set {
+	var set Set
+	for c := range ClassTable {
+		if ClassTable[c]&mask != 0 {
+			set.Add(byte(c))
+		}
+	}
+	return set
+}
+
+// ParseCharlist 解析 PHP 字符列表，与 trim()、addcslashes()、strspn()、ucwords() 等函数的 charlist 参数一致(对应 PHP 源码中的 php_charmask())
+// - "a..z" 表示 'a' 到 'z' 的范围(含两端)
+// - 不合法的范围(e.g. "..z"、"a.."、"z..a"、"a..b..c")返回错误，此时集合仍按 PHP 的行为构建: 跳过首个 '.'，其余字符按普通字符处理
+// 存在多个错误时只返回第一个
+func ParseCharlist[S ~string | ~[]byte](charlist S) (Set, error) {
+	var set Set
+	var err error
+	setErr := func(e error) {
+		if err == nil {
+			err = e
+		}
+	}
+
+	n := len(charlist)
+	for i := 0; i < n; i++ {
+		c := charlist[i]
+		switch {
+		case i+3 < n && charlist[i+1] == '.' && charlist[i+2] == '.' && charlist[i+3] >= c:
+			set.AddRange(c, charlist[i+3])
+			i += 3
+		case i+1 < n && c == '.' && charlist[i+1] == '.':
+			switch {
+			case i == 0:
+				setErr(ErrRangeNoLeft)
+			case i+2 >= n:
+				setErr(ErrRangeNoRight)
+			case charlist[i-1] > charlist[i+2]:
+				setErr(ErrRangeDecreasing)
+			default:
+				setErr(ErrRangeInvalid)
+			}
+		default:
+			set.Add(c)
+		}
+	}
+	return set, err
+}
+
+// Add 添加字节
+func (s *Set) Add(c byte) {
+	s[c>>6] |= 1 << (c & 63)
+}
+
+// AddRange 添加 [lo, hi] 范围内的所有字节，lo > hi 时不添加
+func (s *Set) AddRange(lo, hi byte) {
+	for c := int(lo); c <= int(hi); c++ {
+		s.Add(byte(c))
+	}
+}
+
+// Remove 移除字节
+func (s *Set) Remove(c byte) {
+	s[c>>6] &^= 1 << (c & 63)
+}
+
+// Contains 判断是否包含字节
+func (s Set) Contains(c byte) bool {
+	return s[c>>6]&(1<<(c&63)) != 0
+}
+
+// ContainsRune 判断是否包含字符，超出字节范围的字符返回 false
+func (s Set) ContainsRune(r rune) bool {
+	return 0 <= r && r <= 0xff && s.Contains(byte(r))
+}
+
+// Union 返回并集
+func (s Set) Union(o Set) Set {
+	return Set{s[0] | o[0], s[1] | o[1], s[2] | o[2], s[3] | o[3]}
+}
+
+// Intersect 返回交集
+func (s Set) Intersect(o Set) Set {
+	return Set{s[0] & o[0], s[1] & o[1], s[2] & o[2], s[3] & o[3]}
+}
+
+// Difference 返回差集，即属于 s 但不属于 o 的字节
+func (s Set) Difference(o Set) Set {
+	return Set{s[0] &^ o[0], s[1] &^ o[1], s[2] &^ o[2], s[3] &^ o[3]}
+}
+
+// Complement 返回补集
+func (s Set) Complement() Set {
+	return Set{^s[0], ^s[1], ^s[2], ^s[3]}
+}
+
+// Len 返回集合中的字节数
+func (s Set) Len() int {
+	return bits.OnesCount64(s[0]) + bits.OnesCount64(s[1]) + bits.OnesCount64(s[2]) + bits.OnesCount64(s[3])
+}
+
+// IsEmpty 判断是否为空集合
+func (s Set) IsEmpty() bool {
+	return s[0]|s[1]|s[2]|s[3] == 0
+}
+
+// All 按从小到大的顺序遍历集合中的字节
+func (s Set) All() iter.Seq[byte] {
+	return func(yield func(byte) bool) {
+		for i, word := range s {
+			for word != 0 {
+				bit := bits.TrailingZeros64(word)
+				if !yield(byte(i<<6 | bit)) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// String 返回集合中所有字节按从小到大顺序组成的字符串
+func (s Set) String() string {
+	buf := make([]byte, 0, s.Len())
+	for c := range s.All() {
+		buf = append(buf, c)
+	}
+	return string(buf)
+}
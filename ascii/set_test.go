@@ -0,0 +1,130 @@
+package ascii
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseCharlist(t *testing.T) {
+	tests := []struct {
+		charlist string
+		want     string
+		wantErr  error
+	}{
+		{"", "", nil},
+		{"abc", "abc", nil},
+		{"cba", "abc", nil},
+		{"a..e", "abcde", nil},
+		{"a..a", "a", nil},
+		{"a..zA..Z0..9_", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz", nil},
+		{"\x00..\x03", "\x00\x01\x02\x03", nil},
+		{"\xfe..\xff", "\xfe\xff", nil},
+		{".", ".", nil},
+		{"a.", ".a", nil},
+		{"...", ".", ErrRangeNoLeft},
+		{"...a", "./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`a", nil},
+		{"..z", ".z", ErrRangeNoLeft},
+		{"a..", ".a", ErrRangeNoRight},
+		{"z..a", ".az", ErrRangeDecreasing},
+		{"a..c..e", ".abce", ErrRangeInvalid},
+		{"..", ".", ErrRangeNoLeft},
+	}
+	for _, tt := range tests {
+		got, err := ParseCharlist(tt.charlist)
+		if got.String() != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseCharlist(%q) = %q, %v, want %q, %v", tt.charlist, got.String(), err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSet("abcxyz")
+	b := NewSet([]byte("xyz123"))
+
+	if got := a.Union(b).String(); got != "123abcxyz" {
+		t.Errorf("Union() = %q", got)
+	}
+	if got := a.Intersect(b).String(); got != "xyz" {
+		t.Errorf("Intersect() = %q", got)
+	}
+	if got := a.Difference(b).String(); got != "abc" {
+		t.Errorf("Difference() = %q", got)
+	}
+	c := a.Complement()
+	if c.Len() != 250 || c.Contains('a') || !c.Contains('d') || !c.Contains(0xff) {
+		t.Errorf("Complement() = %v", c)
+	}
+	if c.Complement() != a {
+		t.Errorf("Complement().Complement() != original")
+	}
+
+	var s Set
+	if !s.IsEmpty() || s.Len() != 0 || s.String() != "" {
+		t.Errorf("zero Set should be empty")
+	}
+	s.Add(0)
+	s.Add(0xff)
+	s.AddRange('0', '2')
+	s.AddRange('9', '0')
+	if s.String() != "\x00012\xff" || s.IsEmpty() {
+		t.Errorf("Add() = %q", s.String())
+	}
+	s.Remove('1')
+	if s.Contains('1') || !s.ContainsRune('2') || s.ContainsRune(0x100) || s.ContainsRune(-1) {
+		t.Errorf("Remove() = %q", s.String())
+	}
+
+	var all Set
+	all.AddRange(0, 0xff)
+	if all.Len() != 256 || all.Complement() != (Set{}) {
+		t.Errorf("AddRange(0, 0xff).Len() = %d", all.Len())
+	}
+
+	// 提前终止遍历
+	var first []byte
+	for c := range all.All() {
+		first = append(first, c)
+		if len(first) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(first, []byte{0, 1, 2}) {
+		t.Errorf("All() = %v", first)
+	}
+}
+
+func TestPredefinedSets(t *testing.T) {
+	tests := []struct {
+		name string
+		set  Set
+		fn   func(byte) bool
+	}{
+		{"LowerSet", LowerSet, IsLower[byte]},
+		{"UpperSet", UpperSet, IsUpper[byte]},
+		{"AlphaSet", AlphaSet, IsAlpha[byte]},
+		{"DigitSet", DigitSet, IsDigit[byte]},
+		{"AlphaNumSet", AlphaNumSet, IsAlphaNum[byte]},
+		{"XDigitSet", XDigitSet, IsXDigit[byte]},
+		{"OctDigitSet", OctDigitSet, IsOctDigit[byte]},
+		{"BinDigitSet", BinDigitSet, IsBinDigit[byte]},
+		{"ControlSet", ControlSet, IsControl[byte]},
+		{"SpaceSet", SpaceSet, IsSpace[byte]},
+		{"BlankSet", BlankSet, IsBlank[byte]},
+		{"PunctSet", PunctSet, IsPunct[byte]},
+		{"PrintSet", PrintSet, IsPrint[byte]},
+		{"GraphSet", GraphSet, IsGraph[byte]},
+		{"AsciiSet", AsciiSet, IsAscii[byte]},
+	}
+	for _, tt := range tests {
+		for c := 0; c < 256; c++ {
+			if tt.set.Contains(byte(c)) != tt.fn(byte(c)) {
+				t.Errorf("%s.Contains(%q) != predicate", tt.name, c)
+			}
+		}
+	}
+
+	if ClassSet(ClassAlnum) != AlphaNumSet || ClassSet(ClassPunct) != PunctSet {
+		t.Errorf("ClassSet() mismatch with predefined sets")
+	}
+}
@@ -0,0 +1,126 @@
+package ascii
+
+// 本文件内是以 SWAR 方式一次判断 8 个字节所属字符类别的辅助函数，通用部分见 internal/swar，结果与 ClassTable 查表完全一致
+
+import (
+	"github.com/heyuuu/gophp-utils/internal/swar"
+	"math/bits"
+)
+
+// maxSwarRanges 单次扫描最多合并判断的字节范围数，超过时逐字节查表反而更快
+const maxSwarRanges = 8
+
+// swarSpaceMask 返回 x 中空白字符(IsSpace)的字节掩码
+func swarSpaceMask(x uint64) uint64 {
+	return swar.RangeMask(x, '\t', '\r') | swar.RangeMask(x, ' ', ' ')
+}
+
+// classRanges 每个字符类别对应的 ASCII 字节范围，与 ClassTable 一致
+var classRanges = [...]struct {
+	class  Class
+	lo, hi byte
+}{
+	{ClassUpper, 'A', 'Z'},
+	{ClassLower, 'a', 'z'},
+	{ClassDigit, '0', '9'},
+	{ClassXDigit, '0', '9'},
+	{ClassXDigit, 'A', 'F'},
+	{ClassXDigit, 'a', 'f'},
+	{ClassOctDigit, '0', '7'},
+	{ClassBinDigit, '0', '1'},
+	{ClassSpace, '\t', '\r'},
+	{ClassSpace, ' ', ' '},
+	{ClassBlank, '\t', '\t'},
+	{ClassBlank, ' ', ' '},
+	{ClassPunct, '!', '/'},
+	{ClassPunct, ':', '@'},
+	{ClassPunct, '[', '`'},
+	{ClassPunct, '{', '~'},
+	{ClassCntrl, 0x00, 0x1f},
+	{ClassCntrl, 0x7f, 0x7f},
+	{ClassPrint, ' ', '~'},
+	{ClassGraph, '!', '~'},
+}
+
+// swarMatcher 以若干 ASCII 字节范围描述的字节集合，可一次判断 8 个字节
+// 每个范围 [lo, hi] 预先计算好 swar.RangeMask 中的两个加数，判断时无需重复计算
+type swarMatcher struct {
+	ranges [maxSwarRanges][2]uint64
+	n      int
+	first  byte // 最后一个范围的首字节，用于合并相邻范围
+	last   byte // 最后一个范围的末字节
+	high   bool // 非 ASCII 字节 0x80-0xff 是否全部属于集合
+	ok     bool // 集合能否用 SWAR 判断，为 false 时只能逐字节查表
+}
+
+// swarRange 返回范围 [lo, hi] 在 swar.RangeMask 中对应的两个加数
+func swarRange(lo, hi byte) [2]uint64 {
+	return [2]uint64{swar.Ones * uint64(0x80-lo), swar.Ones * uint64(0x80-hi-1)}
+}
+
+// add 添加字节范围 [lo, hi]，与上一个范围相邻时合并；范围数超出上限时标记为不可用
+func (m *swarMatcher) add(lo, hi byte) {
+	if m.n > 0 && m.last+1 == lo {
+		m.ranges[m.n-1] = swarRange(m.first, hi)
+	} else if m.n == maxSwarRanges {
+		m.ok = false
+		return
+	} else {
+		m.ranges[m.n] = swarRange(lo, hi)
+		m.first = lo
+		m.n++
+	}
+	m.last = hi
+}
+
+// classMatcher 返回类别 mask 对应的 swarMatcher
+func classMatcher(mask Class) swarMatcher {
+	m := swarMatcher{ok: true}
+	for _, r := range classRanges {
+		if r.class&mask != 0 && m.ok {
+			m.add(r.lo, r.hi)
+		}
+	}
+	return m
+}
+
+// setMatcher 返回集合 set 对应的 swarMatcher；非 ASCII 部分必须为全空或全满，否则不可用
+func setMatcher(set Set) swarMatcher {
+	var m swarMatcher
+	switch {
+	case set[2] == 0 && set[3] == 0:
+	case set[2] == ^uint64(0) && set[3] == ^uint64(0):
+		m.high = true
+	default:
+		return m
+	}
+
+	m.ok = true
+	for c := 0; c < 0x80 && m.ok; {
+		w := set[c/64] >> (c % 64)
+		if w == 0 {
+			c = (c/64 + 1) * 64
+			continue
+		}
+		c += bits.TrailingZeros64(w)
+		lo := c
+		// 移位补入的高位 0 取反后为 1，连续段最多延伸到当前 word 末尾，跨 word 的段由 add 合并
+		c += bits.TrailingZeros64(^(set[c/64] >> (c % 64)))
+		m.add(byte(lo), byte(c-1))
+	}
+	return m
+}
+
+// mask 返回 x 中属于集合的字节掩码，命中的字节为 0x80，其他字节为 0x00
+func (m *swarMatcher) mask(x uint64) uint64 {
+	y := x &^ swar.High
+	var r uint64
+	for _, rg := range m.ranges[:m.n] {
+		r |= (y + rg[0]) &^ (y + rg[1])
+	}
+	r = r &^ x & swar.High
+	if m.high {
+		r |= x & swar.High
+	}
+	return r
+}
@@ -0,0 +1,146 @@
+package asciicase
+
+// 本包是 xstrings 和 xbytes 共享的大小写处理实现，包括分词及单词大小写处理逻辑。
+// 约定与 xstrings 一致: 大小写转换只处理 ASCII 范围内的字符；分词时将非 ASCII 字符作为单独类型。
+
+import "github.com/heyuuu/gophp-utils/ascii"
+
+// 字符类别判断及大小写转换统一使用 ascii 包，本包不维护查找表
+
+const (
+	stateSeparator = iota // start or ' ' or '-' or '_'
+	stateLower
+	stateUpper
+	stateDigit
+	stateOthers
+)
+
+// wordClassMask 决定分词状态的字符类别
+const wordClassMask = ascii.ClassUpper | ascii.ClassLower | ascii.ClassDigit
+
+// classStates 以 ascii.ClassTable 中 wordClassMask 部分为下标的分词状态
+var classStates = [wordClassMask + 1]int{
+	0:                stateOthers,
+	ascii.ClassUpper: stateUpper,
+	ascii.ClassLower: stateLower,
+	ascii.ClassDigit: stateDigit,
+}
+
+// byteState 返回字符对应的分词状态
+func byteState(c byte) int {
+	if c == ' ' || c == '-' || c == '_' {
+		return stateSeparator
+	}
+	return classStates[ascii.ClassTable[c]&wordClassMask]
+}
+
+// SplitWords 分词，返回字符串切分后的单词列表，单词均为原字符串的子串
+func SplitWords[S ~string | ~[]byte](s S) []S {
+	var result []S
+	var state int = stateSeparator
+	var wordStart int = 0
+	for i := 0; i < len(s); i++ {
+		nextState := byteState(s[i])
+		if state == nextState {
+			continue
+		}
+
+		// AAAaa 形式会拆分为 "AA" + "Aaa"
+		if state == stateUpper && (nextState == stateLower) {
+			if wordStart < i-1 {
+				result = append(result, s[wordStart:i-1])
+			}
+
+			state = nextState
+			wordStart = i - 1
+		} else {
+			if state != stateSeparator {
+				result = append(result, s[wordStart:i])
+			}
+
+			state = nextState
+			wordStart = i
+		}
+
+	}
+	if state != stateSeparator {
+		result = append(result, s[wordStart:])
+	}
+	return result
+}
+
+// JoinWords 以分隔符连接单词并对英文单词应用 case 处理函数，无单词时返回 nil
+// @param words 单词列表
+// @param sep 分隔符
+// @param caseHandler 单词case处理函数
+func JoinWords[S ~string | ~[]byte](words []S, sep string, caseHandler func(i int, word []byte)) []byte {
+	if len(words) == 0 {
+		return nil
+	}
+
+	// 预计算结果字符串尺寸
+	size := 0
+	for i, word := range words {
+		if i > 0 {
+			size += len(sep)
+		}
+		size += len(word)
+	}
+
+	//
+	buf := make([]byte, 0, size)
+	for i, word := range words {
+		// 添加分隔符
+		if i > 0 {
+			buf = append(buf, sep...)
+		}
+
+		// 非英文单词不处理大小写
+		if !ascii.IsAlpha(word[0]) {
+			buf = append(buf, word...)
+			continue
+		}
+
+		// 英文单词处理大小写
+		wordStart := len(buf)
+		buf = append(buf, word...)
+		caseHandler(i, buf[wordStart:])
+	}
+	return buf
+}
+
+// CamelWord 小驼峰单词处理: 首个单词全小写，其他单词首字母大写
+func CamelWord(wordIndex int, word []byte) {
+	for charIndex, c := range word {
+		if charIndex == 0 && wordIndex > 0 {
+			word[charIndex] = ascii.ToUpper(c)
+		} else {
+			word[charIndex] = ascii.ToLower(c)
+		}
+	}
+}
+
+// PascalWord 大驼峰单词处理: 所有单词首字母大写
+func PascalWord(wordIndex int, word []byte) {
+	for charIndex, c := range word {
+		if charIndex == 0 {
+			word[charIndex] = ascii.ToUpper(c)
+		} else {
+			word[charIndex] = ascii.ToLower(c)
+		}
+	}
+}
+
+// LowerWord 单词全小写
+func LowerWord(wordIndex int, word []byte) {
+	for charIndex, c := range word {
+		word[charIndex] = ascii.ToLower(c)
+	}
+}
+
+// UpperWord 单词全大写
+func UpperWord(wordIndex int, word []byte) {
+	for charIndex, c := range word {
+		word[charIndex] = ascii.ToUpper(c)
+	}
+}
@@ -0,0 +1,27 @@
+package swar
+
+// 本包是 ascii 和 xstrings 共享的 SWAR (SIMD Within A Register) 辅助函数，将 8 个字节装入一个 uint64 并行处理。
+// 所有计算都保证字节间不产生进位，对非 ASCII 字节(最高位为 1)的结果恒为 false，与查找表实现完全一致。
+
+const (
+	Ones = 0x0101010101010101
+	High = 0x8080808080808080
+)
+
+// Load64 以小端序读取 s[i:i+8]，即 s[i] 位于最低字节
+func Load64[S ~string | ~[]byte](s S, i int) uint64 {
+	b := s[i : i+8] // 先切片再取值，编译器可消除逐字节边界检查并合并为单次 8 字节读取
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
+
+// RangeMask 返回 x 中取值在 [lo, hi] 范围内的 ASCII 字节掩码，命中的字节为 0x80，其他字节为 0x00
+// 要求 lo、hi 均为 ASCII 字符且 lo <= hi
+func RangeMask(x uint64, lo, hi byte) uint64 {
+	// 清除最高位后每个字节不超过 0x7f，加上不超过 0x80 的常量也不会向相邻字节进位
+	y := x &^ High
+	geLo := y + Ones*uint64(0x80-lo)   // 字节 >= lo 时最高位为 1
+	gtHi := y + Ones*uint64(0x80-hi-1) // 字节 > hi 时最高位为 1
+	// 排除原本最高位为 1 的非 ASCII 字节
+	return geLo &^ gtHi &^ x & High
+}
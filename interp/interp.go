@@ -0,0 +1,272 @@
+package interp
+
+// PHP 字符串变量插值解析
+// 将双引号字符串、heredoc 的字符串体拆分为字面量和表达式片段，规则与 PHP 词法分析器一致:
+// - 简单语法: $var、$var[offset]、$var->prop、$var?->prop
+// - 复杂语法: {$expr}、${expr}
+// 字面量片段保留原始文本，不处理转义序列；但会识别 '\' 转义，被转义的 '$' 和 '{' 不会触发插值
+
+import (
+	"fmt"
+	"github.com/heyuuu/gophp-utils/ascii"
+)
+
+// Kind 片段类型
+type Kind int
+
+const (
+	Literal      Kind = iota // 字面量
+	SimpleVar                // $var
+	SimpleOffset             // $var[offset]
+	SimpleProp               // $var->prop 或 $var?->prop
+	ComplexExpr              // {$expr}
+	DollarBrace              // ${expr}
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Literal:
+		return "Literal"
+	case SimpleVar:
+		return "SimpleVar"
+	case SimpleOffset:
+		return "SimpleOffset"
+	case SimpleProp:
+		return "SimpleProp"
+	case ComplexExpr:
+		return "ComplexExpr"
+	case DollarBrace:
+		return "DollarBrace"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+// KeyKind SimpleOffset 片段的下标类型
+type KeyKind int
+
+const (
+	KeyNone   KeyKind = iota // 非 SimpleOffset 片段
+	KeyName                  // 未加引号的字符串键，e.g. $arr[key]
+	KeyNumber                // 数字键，e.g. $arr[0]、$arr[-1]、$arr[0x1A]
+	KeyVar                   // 变量键，e.g. $arr[$i]
+)
+
+// Segment 插值片段
+type Segment struct {
+	Kind  Kind
+	Start int    // 片段在原字符串中的起始字节偏移
+	End   int    // 片段在原字符串中的结束字节偏移(不含)
+	Raw   string // 片段原始文本，即 s[Start:End]
+
+	// 简单语法及 ${name} 形式中的变量名(不含 '$')
+	Name string
+	// SimpleOffset 的下标原始文本(KeyVar 时不含 '$')，或 SimpleProp 的属性名
+	Key     string
+	KeyKind KeyKind
+	// SimpleProp 是否为 nullsafe 形式 ?->
+	NullSafe bool
+	// 复杂语法中花括号内的表达式文本
+	Expr string
+}
+
+// SyntaxError 插值语法错误
+type SyntaxError struct {
+	Offset int // 出错位置的字节偏移
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("interp: %s at offset %d", e.Msg, e.Offset)
+}
+
+// scanLabel 返回 s 从 i 开始的 PHP 标签的结束位置，不是标签时返回 i
+func scanLabel(s string, i int) int {
+	if i >= len(s) {
+		return i
+	}
+	return i + ascii.ScanLabel(s[i:])
+}
+
+// Tokenize 解析字符串体中的变量插值，返回按顺序排列的片段列表
+// 相邻的字面量会合并为一个片段；空字符串返回 nil
+func Tokenize(s string) ([]Segment, error) {
+	var segments []Segment
+	literalStart := 0
+	flushLiteral := func(end int) {
+		if literalStart < end {
+			segments = append(segments, Segment{Kind: Literal, Start: literalStart, End: end, Raw: s[literalStart:end]})
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		var seg Segment
+		var err error
+		switch {
+		case c == '\\':
+			// 转义字符，跳过被转义的字节
+			i += 2
+			continue
+		case c == '$' && i+1 < len(s) && ascii.IsLabelStart(s[i+1]):
+			seg, err = parseSimple(s, i)
+		case c == '$' && i+1 < len(s) && s[i+1] == '{':
+			seg, err = parseDollarBrace(s, i)
+		case c == '{' && i+1 < len(s) && s[i+1] == '$':
+			seg, err = parseComplex(s, i)
+		default:
+			i++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		flushLiteral(i)
+		seg.Raw = s[seg.Start:seg.End]
+		segments = append(segments, seg)
+		i = seg.End
+		literalStart = i
+	}
+	flushLiteral(len(s))
+	return segments, nil
+}
+
+// parseSimple 解析简单语法，s[start] 为 '$' 且其后为标签
+func parseSimple(s string, start int) (Segment, error) {
+	nameEnd := scanLabel(s, start+1)
+	seg := Segment{Kind: SimpleVar, Start: start, End: nameEnd, Name: s[start+1 : nameEnd]}
+
+	i := nameEnd
+	switch {
+	case i < len(s) && s[i] == '[':
+		return parseOffset(s, seg)
+	case i+2 < len(s) && s[i] == '-' && s[i+1] == '>' && ascii.IsLabelStart(s[i+2]):
+		propEnd := scanLabel(s, i+2)
+		seg.Kind, seg.End, seg.Key = SimpleProp, propEnd, s[i+2:propEnd]
+	case i+3 < len(s) && s[i] == '?' && s[i+1] == '-' && s[i+2] == '>' && ascii.IsLabelStart(s[i+3]):
+		propEnd := scanLabel(s, i+3)
+		seg.Kind, seg.End, seg.Key, seg.NullSafe = SimpleProp, propEnd, s[i+3:propEnd], true
+	}
+	return seg, nil
+}
+
+// parseOffset 解析简单语法的下标部分，s[seg.End] 为 '['
+func parseOffset(s string, seg Segment) (Segment, error) {
+	keyStart := seg.End + 1
+	i := keyStart
+	switch {
+	case i < len(s) && s[i] == '$' && i+1 < len(s) && ascii.IsLabelStart(s[i+1]):
+		seg.KeyKind = KeyVar
+		keyStart++
+		i = scanLabel(s, keyStart)
+	case i < len(s) && ascii.IsLabelStart(s[i]):
+		seg.KeyKind = KeyName
+		i = scanLabel(s, i)
+	case i < len(s) && (ascii.IsDigit(s[i]) || s[i] == '-'):
+		// 数字键，包含负数及 0x/0b/0o 前缀和 '_' 分隔符形式
+		if s[i] == '-' {
+			i++
+			if i >= len(s) || !ascii.IsDigit(s[i]) {
+				return seg, &SyntaxError{Offset: i, Msg: "expected digit after '-' in offset"}
+			}
+		}
+		i = scanNumber(s, i)
+		seg.KeyKind = KeyNumber
+	default:
+		return seg, &SyntaxError{Offset: i, Msg: "unexpected character in offset"}
+	}
+
+	if i >= len(s) || s[i] != ']' {
+		return seg, &SyntaxError{Offset: i, Msg: "expected ']' after offset"}
+	}
+	seg.Kind = SimpleOffset
+	seg.Key = s[keyStart:i]
+	seg.End = i + 1
+	return seg, nil
+}
+
+// scanNumber 扫描 s[i:] 处的整数字面量，返回结束位置，s[i] 必须为数字
+// 与 PHP 词法中的 LNUM/HNUM/BNUM/ONUM 一致: 支持 0x/0b/0o 前缀(不区分大小写)，'_' 只能出现在两个数字之间
+func scanNumber(s string, i int) int {
+	base := 10
+	if i+2 < len(s) && s[i] == '0' {
+		switch s[i+1] {
+		case 'x', 'X':
+			base = 16
+		case 'b', 'B':
+			base = 2
+		case 'o', 'O':
+			base = 8
+		}
+		// 前缀后没有合法数字时只匹配 "0"
+		if _, ok := ascii.ParseDigit(s[i+2], base); ok && base != 10 {
+			i += 2
+		} else {
+			base = 10
+		}
+	}
+
+	isDigit := func(c byte) bool {
+		_, ok := ascii.ParseDigit(c, base)
+		return ok
+	}
+	for i < len(s) && isDigit(s[i]) {
+		i++
+		if i+1 < len(s) && s[i] == '_' && isDigit(s[i+1]) {
+			i++
+		}
+	}
+	return i
+}
+
+// parseComplex 解析 {$expr} 形式，s[start] 为 '{'
+func parseComplex(s string, start int) (Segment, error) {
+	end, err := matchBrace(s, start)
+	if err != nil {
+		return Segment{}, err
+	}
+	return Segment{Kind: ComplexExpr, Start: start, End: end + 1, Expr: s[start+1 : end]}, nil
+}
+
+// parseDollarBrace 解析 ${expr} 形式，s[start] 为 '$'
+func parseDollarBrace(s string, start int) (Segment, error) {
+	end, err := matchBrace(s, start+1)
+	if err != nil {
+		return Segment{}, err
+	}
+	seg := Segment{Kind: DollarBrace, Start: start, End: end + 1, Expr: s[start+2 : end]}
+
+	// ${name} 及 ${name[expr]} 中的 name 为变量名，其他形式为可变变量表达式
+	if nameEnd := scanLabel(s, start+2); nameEnd > start+2 && (nameEnd == end || s[nameEnd] == '[') {
+		seg.Name = s[start+2 : nameEnd]
+	}
+	return seg, nil
+}
+
+// matchBrace 查找与 s[start] 处 '{' 匹配的 '}'，跳过表达式中的字符串字面量
+func matchBrace(s string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		case '\'', '"':
+			quote := s[i]
+			for i++; i < len(s) && s[i] != quote; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(s) {
+				return 0, &SyntaxError{Offset: len(s), Msg: "unterminated string in expression"}
+			}
+		}
+	}
+	return 0, &SyntaxError{Offset: start, Msg: "unterminated '{'"}
+}
@@ -0,0 +1,111 @@
+package interp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	lit := func(start int, raw string) Segment {
+		return Segment{Kind: Literal, Start: start, End: start + len(raw), Raw: raw}
+	}
+
+	tests := []struct {
+		name string
+		arg  string
+		want []Segment
+	}{
+		{"", "", nil},
+		{"", "plain text", []Segment{lit(0, "plain text")}},
+		{"", "cost: $ 5, {not} $", []Segment{lit(0, "cost: $ 5, {not} $")}},
+		{"", "Hello {$user->name}, you have $count items in $arr[0]", []Segment{
+			lit(0, "Hello "),
+			{Kind: ComplexExpr, Start: 6, End: 19, Raw: "{$user->name}", Expr: "$user->name"},
+			lit(19, ", you have "),
+			{Kind: SimpleVar, Start: 30, End: 36, Raw: "$count", Name: "count"},
+			lit(36, " items in "),
+			{Kind: SimpleOffset, Start: 46, End: 53, Raw: "$arr[0]", Name: "arr", Key: "0", KeyKind: KeyNumber},
+		}},
+		{"", "$a[key]$a[$i]$a[-1]", []Segment{
+			{Kind: SimpleOffset, Start: 0, End: 7, Raw: "$a[key]", Name: "a", Key: "key", KeyKind: KeyName},
+			{Kind: SimpleOffset, Start: 7, End: 13, Raw: "$a[$i]", Name: "a", Key: "i", KeyKind: KeyVar},
+			{Kind: SimpleOffset, Start: 13, End: 19, Raw: "$a[-1]", Name: "a", Key: "-1", KeyKind: KeyNumber},
+		}},
+		{"", "$a[0x1F]$a[-0b1_0]$a[0O17]$a[1_000]", []Segment{
+			{Kind: SimpleOffset, Start: 0, End: 8, Raw: "$a[0x1F]", Name: "a", Key: "0x1F", KeyKind: KeyNumber},
+			{Kind: SimpleOffset, Start: 8, End: 18, Raw: "$a[-0b1_0]", Name: "a", Key: "-0b1_0", KeyKind: KeyNumber},
+			{Kind: SimpleOffset, Start: 18, End: 26, Raw: "$a[0O17]", Name: "a", Key: "0O17", KeyKind: KeyNumber},
+			{Kind: SimpleOffset, Start: 26, End: 35, Raw: "$a[1_000]", Name: "a", Key: "1_000", KeyKind: KeyNumber},
+		}},
+		{"", "$obj->prop->next $obj?->p $obj->", []Segment{
+			{Kind: SimpleProp, Start: 0, End: 10, Raw: "$obj->prop", Name: "obj", Key: "prop"},
+			lit(10, "->next "),
+			{Kind: SimpleProp, Start: 17, End: 25, Raw: "$obj?->p", Name: "obj", Key: "p", NullSafe: true},
+			lit(25, " "),
+			{Kind: SimpleVar, Start: 26, End: 30, Raw: "$obj", Name: "obj"},
+			lit(30, "->"),
+		}},
+		{"", "${name}${arr['k']}${$x . 'y'}", []Segment{
+			{Kind: DollarBrace, Start: 0, End: 7, Raw: "${name}", Name: "name", Expr: "name"},
+			{Kind: DollarBrace, Start: 7, End: 18, Raw: "${arr['k']}", Name: "arr", Expr: "arr['k']"},
+			{Kind: DollarBrace, Start: 18, End: 29, Raw: "${$x . 'y'}", Expr: "$x . 'y'"},
+		}},
+		{"", "{$a['}']}{$b[fn() => {}]}", []Segment{
+			{Kind: ComplexExpr, Start: 0, End: 9, Raw: "{$a['}']}", Expr: "$a['}']"},
+			{Kind: ComplexExpr, Start: 9, End: 25, Raw: "{$b[fn() => {}]}", Expr: "$b[fn() => {}]"},
+		}},
+		{"", `\$a \{$b} {\$c}`, []Segment{
+			lit(0, `\$a \{`),
+			{Kind: SimpleVar, Start: 6, End: 8, Raw: "$b", Name: "b"},
+			lit(8, `} {\$c}`),
+		}},
+		{"", "$变量", []Segment{
+			{Kind: SimpleVar, Start: 0, End: 7, Raw: "$变量", Name: "变量"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tokenize(tt.arg)
+			if err != nil {
+				t.Fatalf("Tokenize() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeError(t *testing.T) {
+	tests := []struct {
+		name       string
+		arg        string
+		wantOffset int
+	}{
+		{"", "$a[ 0]", 3},
+		{"", "$a['k']", 3},
+		{"", "$a[0", 4},
+		{"", "$a[-x]", 4},
+		{"", "$a[1abc]", 4},
+		{"", "$a[0x]", 4},
+		{"", "$a[1_]", 4},
+		{"", "$a[1__0]", 4},
+		{"", "$a[0b12]", 6},
+		{"", "{$a", 0},
+		{"", "${a", 1},
+		{"", "{$a['}", 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Tokenize(tt.arg)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Tokenize() error = %v, want *SyntaxError", err)
+			}
+			if syntaxErr.Offset != tt.wantOffset {
+				t.Errorf("Tokenize() error offset = %v, want %v", syntaxErr.Offset, tt.wantOffset)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package legacyenc
+
+import (
+	"errors"
+	"github.com/heyuuu/gophp-utils/ascii"
+	"io"
+)
+
+var (
+	ErrInvalidBase32Char    = errors.New("legacyenc: illegal base32 character")
+	ErrInvalidBase32Length  = errors.New("legacyenc: invalid base32 data length")
+	ErrInvalidBase32Padding = errors.New("legacyenc: invalid base32 padding")
+)
+
+// NoPadding 表示不使用填充字符
+const NoPadding rune = -1
+
+// Base32Encoding base32 编码方式，由 32 个字符的字母表和填充字符确定
+// - 编码输出的字母表与 NewBase32Encoding 的参数一致
+// - 解码时字母不区分大小写，忽略 '\r'、'\n'；无论是否设置填充字符，输入均可省略填充
+type Base32Encoding struct {
+	alphabet  string
+	decodeMap [256]byte // 0xff 表示非法字符
+	ignore    ascii.Set // 解码时忽略的字符
+	padChar   rune
+}
+
+// 常用的 base32 编码方式
+var (
+	// StdBase32 RFC 4648 标准 base32 编码，与 encoding/base32.StdEncoding 一致
+	StdBase32 = NewBase32Encoding("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567")
+	// HexBase32 RFC 4648 "Extended Hex" base32 编码，与 encoding/base32.HexEncoding 一致
+	HexBase32 = NewBase32Encoding("0123456789ABCDEFGHIJKLMNOPQRSTUV")
+	// CrockfordBase32 Crockford base32 编码，不使用填充；解码时 'I'、'L' 视为 '1'，'O' 视为 '0'，并忽略 '-'
+	CrockfordBase32 = newCrockfordBase32()
+	// ZBase32 z-base-32 编码，不使用填充
+	ZBase32 = NewBase32Encoding("ybndrfg8ejkmcpqxot1uwisza345h769").WithPadding(NoPadding)
+)
+
+// NewBase32Encoding 返回使用指定字母表的 base32 编码方式，填充字符为 '='
+// 字母表必须是 32 个不重复的 ASCII 字符，且不能包含 '\r'、'\n' 及填充字符，否则 panic
+func NewBase32Encoding(alphabet string) *Base32Encoding {
+	if len(alphabet) != 32 {
+		panic("legacyenc: base32 alphabet must be 32 bytes long")
+	}
+	enc := &Base32Encoding{alphabet: alphabet, padChar: '='}
+	enc.ignore = ascii.NewSet("\r\n")
+	for i := range enc.decodeMap {
+		enc.decodeMap[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if !ascii.IsAscii(c) || enc.ignore.Contains(c) || c == '=' || enc.decodeMap[c] != 0xff {
+			panic("legacyenc: invalid base32 alphabet")
+		}
+		enc.decodeMap[c] = byte(i)
+	}
+	// 字母不区分大小写，字母表中已有的字符优先
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		other := ascii.ToUpper(c)
+		if other == c {
+			other = ascii.ToLower(c)
+		}
+		if enc.decodeMap[other] == 0xff {
+			enc.decodeMap[other] = byte(i)
+		}
+	}
+	return enc
+}
+
+func newCrockfordBase32() *Base32Encoding {
+	enc := NewBase32Encoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(NoPadding)
+	for _, c := range "IiLl" {
+		enc.decodeMap[c] = 1
+	}
+	for _, c := range "Oo" {
+		enc.decodeMap[c] = 0
+	}
+	enc.ignore.Add('-')
+	return enc
+}
+
+// WithPadding 返回使用指定填充字符的副本，padding 为 NoPadding 时不使用填充
+// 填充字符必须是不在字母表中的 ASCII 字符，否则 panic
+func (enc Base32Encoding) WithPadding(padding rune) *Base32Encoding {
+	if padding != NoPadding && (!ascii.IsAscii(padding) || enc.decodeMap[padding] != 0xff || enc.ignore.ContainsRune(padding)) {
+		panic("legacyenc: invalid base32 padding")
+	}
+	enc.padChar = padding
+	return &enc
+}
+
+// EncodeToString base32 编码
+func (enc *Base32Encoding) EncodeToString(src []byte) string {
+	dst, _ := transform(&base32Encoder{enc: enc}, src)
+	return string(dst)
+}
+
+// DecodeString base32 解码
+func (enc *Base32Encoding) DecodeString(s string) ([]byte, error) {
+	return transform(&base32Decoder{enc: enc}, s)
+}
+
+// NewEncoder 返回流式 base32 编码器，写入的数据编码后写入 w
+// 写入结束后必须调用 Close 以输出最后一组及填充
+func (enc *Base32Encoding) NewEncoder(w io.Writer) io.WriteCloser {
+	return newWriter(w, &base32Encoder{enc: enc})
+}
+
+// NewDecoder 返回流式 base32 解码器，从 r 读取数据并解码
+func (enc *Base32Encoding) NewDecoder(r io.Reader) io.Reader {
+	return newReader(r, &base32Decoder{enc: enc})
+}
+
+type base32Encoder struct {
+	enc   *Base32Encoding
+	group [5]byte
+	n     int // group 中的字节数
+}
+
+func (e *base32Encoder) feed(dst []byte, src []byte, atEOF bool) ([]byte, error) {
+	for _, c := range src {
+		e.group[e.n] = c
+		e.n++
+		if e.n == len(e.group) {
+			dst = e.encodeGroup(dst)
+		}
+	}
+	if atEOF && e.n > 0 {
+		dst = e.encodeGroup(dst)
+	}
+	return dst, nil
+}
+
+// encodeGroup 编码最多 5 字节为 8 个字符，不足 5 字节时按需填充
+func (e *base32Encoder) encodeGroup(dst []byte) []byte {
+	clear(e.group[e.n:])
+	b := e.group
+	chars := [8]byte{
+		b[0] >> 3,
+		b[0]<<2&0x1f | b[1]>>6,
+		b[1] >> 1 & 0x1f,
+		b[1]<<4&0x1f | b[2]>>4,
+		b[2]<<1&0x1f | b[3]>>7,
+		b[3] >> 2 & 0x1f,
+		b[3]<<3&0x1f | b[4]>>5,
+		b[4] & 0x1f,
+	}
+	// 1~5 字节分别对应 2、4、5、7、8 个有效字符
+	used := (e.n*8 + 4) / 5
+	for _, c := range chars[:used] {
+		dst = append(dst, e.enc.alphabet[c])
+	}
+	if e.enc.padChar != NoPadding {
+		for range 8 - used {
+			dst = append(dst, byte(e.enc.padChar))
+		}
+	}
+	e.n = 0
+	return dst
+}
+
+type base32Decoder struct {
+	enc    *Base32Encoding
+	group  [8]byte
+	n      int  // group 中的字符数
+	padded int  // 已读取的填充字符数
+	end    bool // 已解码最后一组(含填充)，之后只允许忽略字符
+}
+
+func (d *base32Decoder) feed(dst []byte, src []byte, atEOF bool) ([]byte, error) {
+	enc := d.enc
+	for _, c := range src {
+		switch {
+		case enc.ignore.Contains(c):
+			continue
+		case d.end:
+			return dst, ErrInvalidBase32Padding
+		case enc.padChar != NoPadding && rune(c) == enc.padChar:
+			if d.n == 0 {
+				return dst, ErrInvalidBase32Padding
+			}
+			d.padded++
+			if d.n+d.padded == len(d.group) {
+				var err error
+				if dst, err = d.decodeGroup(dst); err != nil {
+					return dst, err
+				}
+				d.end = true
+			}
+		case d.padded > 0:
+			return dst, ErrInvalidBase32Padding
+		case enc.decodeMap[c] == 0xff:
+			return dst, ErrInvalidBase32Char
+		default:
+			d.group[d.n] = enc.decodeMap[c]
+			d.n++
+			if d.n == len(d.group) {
+				dst, _ = d.decodeGroup(dst)
+			}
+		}
+	}
+	if atEOF {
+		if d.padded > 0 {
+			return dst, ErrInvalidBase32Padding
+		}
+		if d.n > 0 {
+			return d.decodeGroup(dst)
+		}
+	}
+	return dst, nil
+}
+
+// decodeGroup 解码最多 8 个字符，有效字符数只能为 2、4、5、7、8
+func (d *base32Decoder) decodeGroup(dst []byte) ([]byte, error) {
+	var size int
+	switch d.n {
+	case 2:
+		size = 1
+	case 4:
+		size = 2
+	case 5:
+		size = 3
+	case 7:
+		size = 4
+	case 8:
+		size = 5
+	default:
+		return dst, ErrInvalidBase32Length
+	}
+	clear(d.group[d.n:])
+	g := d.group
+	b := [5]byte{
+		g[0]<<3 | g[1]>>2,
+		g[1]<<6 | g[2]<<1 | g[3]>>4,
+		g[3]<<4 | g[4]>>1,
+		g[4]<<7 | g[5]<<2 | g[6]>>3,
+		g[6]<<5 | g[7],
+	}
+	d.n, d.padded = 0, 0
+	return append(dst, b[:size]...), nil
+}
@@ -0,0 +1,115 @@
+package legacyenc
+
+import (
+	"bytes"
+	"encoding/base32"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// translate 按下标将 std 字母表的编码结果转换为其他字母表
+func translate(s string, from, to string) string {
+	buf := []byte(s)
+	for i, c := range buf {
+		if j := strings.IndexByte(from, c); j >= 0 {
+			buf[i] = to[j]
+		}
+	}
+	return string(buf)
+}
+
+func TestBase32(t *testing.T) {
+	inputs := []string{"", "f", "fo", "foo", "foob", "fooba", "foobar", "\x00\xff\x10\x80", strings.Repeat("hello, world ", 20)}
+	std := "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	for _, s := range inputs {
+		tests := []struct {
+			name string
+			enc  *Base32Encoding
+			want string
+		}{
+			{"Std", StdBase32, base32.StdEncoding.EncodeToString([]byte(s))},
+			{"Hex", HexBase32, base32.HexEncoding.EncodeToString([]byte(s))},
+			{"StdNoPadding", StdBase32.WithPadding(NoPadding), base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(s))},
+			{"Crockford", CrockfordBase32, translate(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(s)), std, "0123456789ABCDEFGHJKMNPQRSTVWXYZ")},
+			{"ZBase32", ZBase32, translate(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(s)), std, "ybndrfg8ejkmcpqxot1uwisza345h769")},
+		}
+		for _, tt := range tests {
+			if got := tt.enc.EncodeToString([]byte(s)); got != tt.want {
+				t.Errorf("%s.EncodeToString(%q) = %q, want %q", tt.name, s, got, tt.want)
+			}
+			if got, err := tt.enc.DecodeString(tt.want); err != nil || string(got) != s {
+				t.Errorf("%s.DecodeString(%q) = %q, %v, want %q", tt.name, tt.want, got, err, s)
+			}
+
+			var buf bytes.Buffer
+			writeBytewise(t, tt.enc.NewEncoder(&buf), s)
+			if buf.String() != tt.want {
+				t.Errorf("%s.NewEncoder(%q) = %q, want %q", tt.name, s, buf.String(), tt.want)
+			}
+			got, err := io.ReadAll(tt.enc.NewDecoder(oneByteReader{strings.NewReader(tt.want)}))
+			if err != nil || string(got) != s {
+				t.Errorf("%s.NewDecoder(%q) = %q, %v, want %q", tt.name, tt.want, got, err, s)
+			}
+		}
+	}
+}
+
+func TestBase32Decode(t *testing.T) {
+	tests := []struct {
+		enc     *Base32Encoding
+		s       string
+		want    string
+		wantErr error
+	}{
+		{StdBase32, "MZXW6YTBOI======", "foobar", nil},
+		{StdBase32, "MZXW6YTBOI", "foobar", nil},
+		{StdBase32, "mzxw6ytboi", "foobar", nil},
+		{StdBase32, "MZXW6\r\nYTBOI===\n===", "foobar", nil},
+		{StdBase32, "MZXW6YTBO", "", ErrInvalidBase32Length},
+		{StdBase32, "MZXW6YTBOI=", "", ErrInvalidBase32Padding},
+		{StdBase32, "MZXW6YTBOI======MY", "", ErrInvalidBase32Padding},
+		{StdBase32, "MZXW6YTB=OI", "", ErrInvalidBase32Padding},
+		{StdBase32, "========", "", ErrInvalidBase32Padding},
+		{StdBase32, "MZXW6YT1", "", ErrInvalidBase32Char},
+		{StdBase32, "MZXW-6YTB", "", ErrInvalidBase32Char},
+		{StdBase32.WithPadding(NoPadding), "MY======", "", ErrInvalidBase32Char},
+		{StdBase32.WithPadding('*'), "MY******", "f", nil},
+		{CrockfordBase32, "CSQPYRK1E8", "foobar", nil},
+		{CrockfordBase32, "csqp-yrk1-e8", "foobar", nil},
+		{CrockfordBase32, "CSQPYRKIE8", "foobar", nil},
+		{CrockfordBase32, "CSQPYRKlE8", "foobar", nil},
+		{CrockfordBase32, "0000", "\x00\x00", nil},
+		{CrockfordBase32, "OoOo", "\x00\x00", nil},
+		{CrockfordBase32, "CSQPYRKUE8", "", ErrInvalidBase32Char},
+		{ZBase32, "yb0", "", ErrInvalidBase32Char},
+		{ZBase32, "CPNMUOJ1E8", "cD\xb9\xc12A", nil},
+	}
+	for _, tt := range tests {
+		got, err := tt.enc.DecodeString(tt.s)
+		if !errors.Is(err, tt.wantErr) || (err == nil && string(got) != tt.want) {
+			t.Errorf("DecodeString(%q) = %q, %v, want %q, %v", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNewBase32EncodingPanic(t *testing.T) {
+	tests := []func(){
+		func() { NewBase32Encoding("ABC") },
+		func() { NewBase32Encoding("AACDEFGHIJKLMNOPQRSTUVWXYZ234567") },
+		func() { NewBase32Encoding("=BCDEFGHIJKLMNOPQRSTUVWXYZ234567") },
+		func() { StdBase32.WithPadding('A') },
+		func() { StdBase32.WithPadding('\n') },
+	}
+	for i, fn := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("case %d should panic", i)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,165 @@
+package legacyenc
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"io"
+)
+
+// qpMaxLineLen quoted-printable 编码的最大行长度(不含软换行的 '=')，对应 PHP 源码中的 PHP_QPRINT_MAXL
+const qpMaxLineLen = 75
+
+const upperHex = "0123456789ABCDEF"
+
+// EncodeQuotedPrintable quoted-printable 编码，与 PHP 函数 quoted_printable_encode() 逐字节一致
+// - 控制字符、0x7f、0x80 及以上的字节、'=' 以及 CR 前的空格编码为 "=XX"；输入中的 CRLF 原样保留并重置行长度
+// - 行长度超过 75 时插入软换行 "=\r\n"；与 PHP 一致，编码 UTF-8 首字节时会为后续字节预留长度
+func EncodeQuotedPrintable[S ~string | ~[]byte](s S) string {
+	dst, _ := transform(&qpEncoder{}, s)
+	return string(dst)
+}
+
+// DecodeQuotedPrintable quoted-printable 解码，与 PHP 函数 quoted_printable_decode() 一致
+// - "=XX"(不区分大小写)解码为对应字节
+// - '=' 后跟可选的空格/制表符及换行(CRLF、CR 或 LF)或输入结尾时为软换行，整体移除
+// - 其他 '=' 原样保留
+// 与 PHP 不同的是，NUL 字节作为普通字节处理，而不会截断输入
+func DecodeQuotedPrintable[S ~string | ~[]byte](s S) string {
+	dst, _ := transform(&qpDecoder{}, s)
+	return string(dst)
+}
+
+// NewQuotedPrintableEncoder 返回流式 quoted-printable 编码器，写入的数据编码后写入 w
+// 写入结束后必须调用 Close 以输出缓存的尾部数据
+func NewQuotedPrintableEncoder(w io.Writer) io.WriteCloser {
+	return newWriter(w, &qpEncoder{})
+}
+
+// NewQuotedPrintableDecoder 返回流式 quoted-printable 解码器，从 r 读取数据并解码
+func NewQuotedPrintableDecoder(r io.Reader) io.Reader {
+	return newReader(r, &qpDecoder{})
+}
+
+type qpEncoder struct {
+	lineLen int
+	pending []byte // 需要后一字节才能确定编码方式的 '\r' 或 ' '
+}
+
+func (e *qpEncoder) feed(dst []byte, src []byte, atEOF bool) ([]byte, error) {
+	data := src
+	if len(e.pending) > 0 {
+		data = append(e.pending, src...)
+		e.pending = e.pending[:0]
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if (c == '\r' || c == ' ') && i+1 == len(data) && !atEOF {
+			e.pending = append(e.pending, c)
+			break
+		}
+		var next byte
+		if i+1 < len(data) {
+			next = data[i+1]
+		}
+
+		if c == '\r' && next == '\n' {
+			dst = append(dst, '\r', '\n')
+			i++
+			e.lineLen = 0
+		} else if ascii.IsControl(c) || c >= 0x80 || c == '=' || (c == ' ' && next == '\r') {
+			// 与 PHP 一致: 先累加行长度，再按 UTF-8 首字节预留后续字节的长度
+			e.lineLen += 3
+			if (c <= 0x7f && e.lineLen > qpMaxLineLen) ||
+				(c > 0x7f && c <= 0xdf && e.lineLen+3 > qpMaxLineLen) ||
+				(c > 0xdf && c <= 0xef && e.lineLen+6 > qpMaxLineLen) ||
+				(c > 0xef && c <= 0xf4 && e.lineLen+9 > qpMaxLineLen) {
+				dst = append(dst, '=', '\r', '\n')
+				e.lineLen = 3
+			}
+			dst = append(dst, '=', upperHex[c>>4], upperHex[c&0xf])
+		} else {
+			e.lineLen++
+			if e.lineLen > qpMaxLineLen {
+				dst = append(dst, '=', '\r', '\n')
+				e.lineLen = 1
+			}
+			dst = append(dst, c)
+		}
+	}
+	return dst, nil
+}
+
+type qpDecoder struct {
+	pending []byte // 以 '=' 开头、尚不能确定含义的尾部输入
+}
+
+func (d *qpDecoder) feed(dst []byte, src []byte, atEOF bool) ([]byte, error) {
+	data := src
+	if len(d.pending) > 0 {
+		data = append(d.pending, src...)
+		d.pending = d.pending[:0]
+	}
+
+	for i := 0; i < len(data); {
+		if data[i] != '=' {
+			dst = append(dst, data[i])
+			i++
+			continue
+		}
+
+		c, emit, n := qpDecodeEscape(data[i:], atEOF)
+		if n == 0 {
+			d.pending = append(d.pending, data[i:]...)
+			break
+		}
+		if emit {
+			dst = append(dst, c)
+		}
+		i += n
+	}
+	return dst, nil
+}
+
+// qpDecodeEscape 解码以 '=' 开头的 s，返回输出字节(emit 为 false 时无输出)及消耗的字节数；需要更多输入才能确定时返回 n = 0
+func qpDecodeEscape(s []byte, atEOF bool) (c byte, emit bool, n int) {
+	need := func(i int) bool { return i >= len(s) && !atEOF }
+
+	// =XX
+	if need(1) {
+		return 0, false, 0
+	}
+	if len(s) > 1 && ascii.IsXDigit(s[1]) {
+		if need(2) {
+			return 0, false, 0
+		}
+		if len(s) > 2 && ascii.IsXDigit(s[2]) {
+			hi, _ := ascii.ParseXDigit(s[1])
+			lo, _ := ascii.ParseXDigit(s[2])
+			return hi<<4 | lo, true, 3
+		}
+	}
+
+	// 软换行: '=' 后跟可选的空格/制表符及换行或输入结尾
+	k := 1
+	for k < len(s) && (s[k] == ' ' || s[k] == '\t') {
+		k++
+	}
+	switch {
+	case need(k):
+		return 0, false, 0
+	case k == len(s):
+		return 0, false, k
+	case s[k] == '\r':
+		if need(k + 1) {
+			return 0, false, 0
+		}
+		if k+1 < len(s) && s[k+1] == '\n' {
+			return 0, false, k + 2
+		}
+		return 0, false, k + 1
+	case s[k] == '\n':
+		return 0, false, k + 1
+	default:
+		return '=', true, 1
+	}
+}
@@ -0,0 +1,123 @@
+package legacyenc
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEncodeQuotedPrintable(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"Hello World", "Hello World"},
+		{"a=b", "a=3Db"},
+		{"é", "=C3=A9"},
+		{"tab\there", "tab=09here"},
+		{"line1\r\nline2", "line1\r\nline2"},
+		{"line1\nline2\rline3", "line1=0Aline2=0Dline3"},
+		{"trailing \r\nspace", "trailing=20\r\nspace"},
+		{"space ", "space "},
+		{"\r", "=0D"},
+		{"\x00\x7f\xff", "=00=7F=FF"},
+		// 软换行
+		{strings.Repeat("a", 75), strings.Repeat("a", 75)},
+		{strings.Repeat("a", 76), strings.Repeat("a", 75) + "=\r\na"},
+		{strings.Repeat("a", 160), strings.Repeat("a", 75) + "=\r\n" + strings.Repeat("a", 75) + "=\r\n" + strings.Repeat("a", 10)},
+		{strings.Repeat("a", 74) + "=", strings.Repeat("a", 74) + "=\r\n=3D"},
+		{strings.Repeat("a", 72) + "=", strings.Repeat("a", 72) + "=3D"},
+		{strings.Repeat("a", 75) + "\r\n" + strings.Repeat("a", 75), strings.Repeat("a", 75) + "\r\n" + strings.Repeat("a", 75)},
+		// 按 UTF-8 首字节预留后续字节的长度；与 PHP 一致，后续字节同样预留 3 个字符，因此仍可能在字符中间换行
+		{strings.Repeat("é", 30), strings.Repeat("=C3=A9", 12) + "=\r\n" + strings.Repeat("=C3=A9", 12) + "=\r\n" + strings.Repeat("=C3=A9", 6)},
+		{strings.Repeat("a", 70) + "中", strings.Repeat("a", 70) + "=\r\n=E4=B8=AD"},
+		{strings.Repeat("a", 65) + "中", strings.Repeat("a", 65) + "=E4=B8=\r\n=AD"},
+		{strings.Repeat("a", 67) + "😀", strings.Repeat("a", 67) + "=\r\n=F0=9F=98=80"},
+		{strings.Repeat("a", 63) + "😀", strings.Repeat("a", 63) + "=F0=9F=98=\r\n=80"},
+	}
+	for _, tt := range tests {
+		if got := EncodeQuotedPrintable(tt.s); got != tt.want {
+			t.Errorf("EncodeQuotedPrintable(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		if got := DecodeQuotedPrintable(tt.want); got != tt.s {
+			t.Errorf("DecodeQuotedPrintable(%q) = %q, want %q", tt.want, got, tt.s)
+		}
+	}
+}
+
+func TestDecodeQuotedPrintable(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"=3D=3d", "=="},
+		{"=C3=A9t=C3=A9", "été"},
+		{"soft=\r\nbreak", "softbreak"},
+		{"soft=\nbreak", "softbreak"},
+		{"soft=\rbreak", "softbreak"},
+		{"soft= \t \r\nbreak", "softbreak"},
+		{"soft=  \nbreak", "softbreak"},
+		{"end=", "end"},
+		{"end=  ", "end"},
+		{"a=b", "a=b"},
+		{"a=4", "a=4"},
+		{"a=4x", "a=4x"},
+		{"a= b", "a= b"},
+		{"a==41", "a=A"},
+		{"a\x00=41", "a\x00A"},
+	}
+	for _, tt := range tests {
+		if got := DecodeQuotedPrintable(tt.s); got != tt.want {
+			t.Errorf("DecodeQuotedPrintable(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+// oneByteReader 每次只读取一个字节，用于测试流式处理的边界情况
+type oneByteReader struct {
+	r io.Reader
+}
+
+func (r oneByteReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return r.r.Read(p[:1])
+}
+
+// writeBytewise 逐字节写入 w 后关闭
+func writeBytewise(t *testing.T, w io.WriteCloser, s string) {
+	for i := 0; i < len(s); i++ {
+		if _, err := w.Write([]byte{s[i]}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+func TestQuotedPrintableStream(t *testing.T) {
+	inputs := []string{
+		"",
+		"trailing \r\nspace ",
+		"line1\r\nline2\r",
+		strings.Repeat("é", 100) + strings.Repeat("a=b ", 50),
+		"soft= \t \r\nbreak=\r=\n=41=",
+	}
+	for _, s := range inputs {
+		var buf bytes.Buffer
+		writeBytewise(t, NewQuotedPrintableEncoder(&buf), s)
+		if want := EncodeQuotedPrintable(s); buf.String() != want {
+			t.Errorf("QuotedPrintableEncoder(%q) = %q, want %q", s, buf.String(), want)
+		}
+
+		got, err := io.ReadAll(NewQuotedPrintableDecoder(oneByteReader{strings.NewReader(s)}))
+		if want := DecodeQuotedPrintable(s); err != nil || string(got) != want {
+			t.Errorf("QuotedPrintableDecoder(%q) = %q, %v, want %q", s, got, err, want)
+		}
+	}
+}
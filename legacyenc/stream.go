@@ -0,0 +1,99 @@
+package legacyenc
+
+// 旧式文本编码的编解码函数，用于处理邮件及由 PHP 代码生成的历史数据:
+// - quoted-printable: 与 PHP 函数 quoted_printable_encode()、quoted_printable_decode() 一致
+// - uuencode: 与 PHP 函数 convert_uuencode()、convert_uudecode() 一致
+// - base32: RFC 4648 标准及 Extended Hex、Crockford、z-base-32 等变体
+// 每种编码均同时提供一次性处理的函数和流式处理的 io.Writer(编码)、io.Reader(解码)，两者结果逐字节一致
+
+import "io"
+
+// codec 流式编解码状态机，一次性编解码函数与流式 Reader/Writer 共用同一实现
+// feed 处理 src 并将结果追加到 dst 后返回；尚不能确定结果的尾部输入由 codec 自行缓存
+// atEOF 为 true 时表示输入已结束，codec 需输出所有缓存内容
+type codec interface {
+	feed(dst []byte, src []byte, atEOF bool) ([]byte, error)
+}
+
+// transform 一次性处理全部输入
+func transform[S ~string | ~[]byte](c codec, src S) ([]byte, error) {
+	return c.feed(make([]byte, 0, len(src)), []byte(src), true)
+}
+
+// writer 将写入的数据经 codec 处理后写入底层 io.Writer
+type writer struct {
+	w   io.Writer
+	c   codec
+	buf []byte
+	err error
+}
+
+func newWriter(w io.Writer, c codec) io.WriteCloser {
+	return &writer{w: w, c: c}
+}
+
+func (w *writer) flush(p []byte, atEOF bool) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.buf, w.err = w.c.feed(w.buf[:0], p, atEOF)
+	if w.err == nil && len(w.buf) > 0 {
+		_, w.err = w.w.Write(w.buf)
+	}
+	return w.err
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if err := w.flush(p, false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close 输出缓存的剩余内容，不会关闭底层 io.Writer；之后的写入均返回 io.ErrClosedPipe
+func (w *writer) Close() error {
+	if w.err == io.ErrClosedPipe {
+		return nil
+	}
+	if err := w.flush(nil, true); err != nil {
+		return err
+	}
+	w.err = io.ErrClosedPipe
+	return nil
+}
+
+// reader 从底层 io.Reader 读取数据并经 codec 处理后返回
+type reader struct {
+	r   io.Reader
+	c   codec
+	in  []byte
+	buf []byte
+	out []byte // buf 中尚未被读取的部分
+	err error
+}
+
+func newReader(r io.Reader, c codec) io.Reader {
+	return &reader{r: r, c: c, in: make([]byte, 4096)}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		n, err := r.r.Read(r.in)
+		atEOF := err == io.EOF
+		buf, cerr := r.c.feed(r.buf[:0], r.in[:n], atEOF)
+		r.buf, r.out = buf, buf
+		switch {
+		case cerr != nil:
+			r.err = cerr
+		case err != nil:
+			r.err = err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
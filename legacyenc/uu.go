@@ -0,0 +1,171 @@
+package legacyenc
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrInvalidUU = errors.New("legacyenc: the given string is not a valid uuencoded string")
+
+// uuLineLen uuencode 每行编码的最大字节数
+const uuLineLen = 45
+
+// uuEnc 6 位值的 uuencode 编码字符，0 编码为 '`' 而不是空格
+func uuEnc(c byte) byte {
+	if c&077 == 0 {
+		return '`'
+	}
+	return c&077 + ' '
+}
+
+// uuDec uuencode 字符解码为 6 位值
+func uuDec(c byte) byte {
+	return (c - ' ') & 077
+}
+
+// EncodeUU uuencode 编码，与 PHP 函数 convert_uuencode() 逐字节一致
+// 每行以长度字符开头，最多编码 45 字节并以 '\n' 结尾，最后以 "`\n" 结束；空输入返回空字符串
+func EncodeUU[S ~string | ~[]byte](s S) string {
+	dst, _ := transform(&uuEncoder{}, s)
+	return string(dst)
+}
+
+// DecodeUU uuencode 解码，与 PHP 函数 convert_uudecode() 一致
+// - 长度为 0 的行(e.g. "`")结束解码，长度小于 45 的行解码后也结束解码，之后的内容均被忽略
+// - 与 PHP 一致，长度为 45 的行后跳过一个字节而不检查是否为换行符
+// - 行数据不足时返回 ErrInvalidUU
+func DecodeUU[S ~string | ~[]byte](s S) (string, error) {
+	dst, err := transform(&uuDecoder{}, s)
+	if err != nil {
+		return "", err
+	}
+	return string(dst), nil
+}
+
+// NewUUEncoder 返回流式 uuencode 编码器，写入的数据编码后写入 w
+// 写入结束后必须调用 Close 以输出最后一行及结束行
+func NewUUEncoder(w io.Writer) io.WriteCloser {
+	return newWriter(w, &uuEncoder{})
+}
+
+// NewUUDecoder 返回流式 uuencode 解码器，从 r 读取数据并解码
+func NewUUDecoder(r io.Reader) io.Reader {
+	return newReader(r, &uuDecoder{})
+}
+
+type uuEncoder struct {
+	line    []byte // 不足一行的待编码数据
+	written bool
+}
+
+func (e *uuEncoder) feed(dst []byte, src []byte, atEOF bool) ([]byte, error) {
+	for len(src) > 0 {
+		n := min(uuLineLen-len(e.line), len(src))
+		e.line = append(e.line, src[:n]...)
+		src = src[n:]
+		if len(e.line) == uuLineLen {
+			dst = e.encodeLine(dst)
+		}
+	}
+	if atEOF {
+		if len(e.line) > 0 {
+			dst = e.encodeLine(dst)
+		}
+		if e.written {
+			dst = append(dst, '`', '\n')
+		}
+	}
+	return dst, nil
+}
+
+func (e *uuEncoder) encodeLine(dst []byte) []byte {
+	dst = append(dst, uuEnc(byte(len(e.line))))
+	for i := 0; i < len(e.line); i += 3 {
+		var b [3]byte
+		copy(b[:], e.line[i:])
+		dst = append(dst,
+			uuEnc(b[0]>>2),
+			uuEnc(b[0]<<4&060|b[1]>>4&017),
+			uuEnc(b[1]<<2&074|b[2]>>6&03),
+			uuEnc(b[2]&077),
+		)
+	}
+	e.line = e.line[:0]
+	e.written = true
+	return append(dst, '\n')
+}
+
+type uuDecoder struct {
+	pending []byte // 不足一行的待解码数据
+	done    bool   // 已遇到结束行，忽略之后的所有输入
+	decoded int    // 已输出的字节数
+	total   int    // 各行长度之和，即实际输出的字节数
+	extra   []byte // 已解码但超出 total 的字节
+}
+
+func (d *uuDecoder) feed(dst []byte, src []byte, atEOF bool) ([]byte, error) {
+	if d.done {
+		return dst, nil
+	}
+	data := src
+	if len(d.pending) > 0 {
+		data = append(d.pending, src...)
+		d.pending = d.pending[:0]
+	}
+
+	for len(data) > 0 {
+		n := int(uuDec(data[0]))
+		if n == 0 {
+			d.done = true
+			break
+		}
+
+		// 与 PHP 一致: 行数据的字符数为 floor(n * 1.33)，长度为 45 时为 60，按 4 字符一组向上取整
+		chars := int(float64(n) * 1.33)
+		if n == uuLineLen {
+			chars = 60
+		}
+		lineSize := 1 + (chars+3)/4*4
+		if n >= uuLineLen {
+			lineSize++ // 跳过的换行符
+		}
+		if len(data) < lineSize {
+			if !atEOF {
+				break
+			}
+			// 输入结束时，长度不小于 45 的行可以省略末尾的换行符
+			if n < uuLineLen || len(data) < lineSize-1 {
+				return dst, ErrInvalidUU
+			}
+			lineSize--
+		}
+
+		dst = d.decodeLine(dst, n, data[1:1+(chars+3)/4*4])
+		data = data[lineSize:]
+		if n < uuLineLen {
+			d.done = true
+			break
+		}
+	}
+	if !d.done && len(data) > 0 {
+		d.pending = append(d.pending, data...)
+	}
+	return dst, nil
+}
+
+// decodeLine 解码一行数据，与 PHP 一致，各行的解码结果连续拼接，最终输出长度为各行长度之和
+func (d *uuDecoder) decodeLine(dst []byte, n int, line []byte) []byte {
+	d.total += n
+	for i := 0; i < len(line); i += 4 {
+		d.extra = append(d.extra,
+			uuDec(line[i])<<2|uuDec(line[i+1])>>4,
+			uuDec(line[i+1])<<4|uuDec(line[i+2])>>2,
+			uuDec(line[i+2])<<6|uuDec(line[i+3]),
+		)
+	}
+	emit := min(len(d.extra), d.total-d.decoded)
+	dst = append(dst, d.extra[:emit]...)
+	d.decoded += emit
+	d.extra = append(d.extra[:0], d.extra[emit:]...)
+	return dst
+}
@@ -0,0 +1,90 @@
+package legacyenc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEncodeUU(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"a", "!80``\n`\n"},
+		{"ab", "\"86(`\n`\n"},
+		{"abc", "#86)C\n`\n"},
+		{"abcd", "$86)C9```\n`\n"},
+		{"test\ntext text text\r\n", "5=&5S=`IT97AT('1E>'0@=&5X=`T*\n`\n"},
+		{strings.Repeat("\x00", 45), "M" + strings.Repeat("`", 60) + "\n`\n"},
+		{strings.Repeat("\x00", 46), "M" + strings.Repeat("`", 60) + "\n!````\n`\n"},
+		{strings.Repeat("\x00", 93), "M" + strings.Repeat("`", 60) + "\nM" + strings.Repeat("`", 60) + "\n#````\n`\n"},
+	}
+	for _, tt := range tests {
+		if got := EncodeUU(tt.s); got != tt.want {
+			t.Errorf("EncodeUU(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		if got, err := DecodeUU(tt.want); err != nil || got != tt.s {
+			t.Errorf("DecodeUU(%q) = %q, %v, want %q", tt.want, got, err, tt.s)
+		}
+	}
+}
+
+func TestDecodeUU(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr error
+	}{
+		{"", "", nil},
+		{"`", "", nil},
+		{" ", "", nil},
+		// 长度小于 45 的行之后的内容被忽略
+		{"!80``\n!80``\n`\n", "a", nil},
+		{"#86)C", "abc", nil},
+		{"#86)Cgarbage", "abc", nil},
+		// 长度为 45 的行后跳过一个字节而不检查
+		{"M" + strings.Repeat("`", 60) + "x!80``", strings.Repeat("\x00", 45) + "a", nil},
+		{"M" + strings.Repeat("`", 60), strings.Repeat("\x00", 45), nil},
+		// 数据不足
+		{"#", "", ErrInvalidUU},
+		{"#86)", "", ErrInvalidUU},
+		{"M" + strings.Repeat("`", 59), "", ErrInvalidUU},
+		{"abc", "", ErrInvalidUU},
+	}
+	for _, tt := range tests {
+		got, err := DecodeUU(tt.s)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("DecodeUU(%q) = %q, %v, want %q, %v", tt.s, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestUUStream(t *testing.T) {
+	var all strings.Builder
+	for i := 0; i < 1000; i++ {
+		all.WriteByte(byte(i * 7))
+	}
+	inputs := []string{"", "a", "abcd", strings.Repeat("x", 45), all.String()}
+	for _, s := range inputs {
+		var buf bytes.Buffer
+		writeBytewise(t, NewUUEncoder(&buf), s)
+		want := EncodeUU(s)
+		if buf.String() != want {
+			t.Errorf("UUEncoder(%q) = %q, want %q", s, buf.String(), want)
+		}
+
+		got, err := io.ReadAll(NewUUDecoder(oneByteReader{strings.NewReader(want)}))
+		if err != nil || string(got) != s {
+			t.Errorf("UUDecoder(%q) = %q, %v, want %q", want, got, err, s)
+		}
+	}
+
+	_, err := io.ReadAll(NewUUDecoder(strings.NewReader("#86)")))
+	if !errors.Is(err, ErrInvalidUU) {
+		t.Errorf("UUDecoder() error = %v, want %v", err, ErrInvalidUU)
+	}
+}
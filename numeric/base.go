@@ -0,0 +1,155 @@
+package numeric
+
+// 本文件内是任意进制整数转换相关的函数，与 PHP 函数 bindec()、hexdec()、octdec()、base_convert()、decbin()、dechex()、decoct() 一致
+
+import (
+	"errors"
+	"github.com/heyuuu/gophp-utils/ascii"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrInvalidBase    = errors.New("numeric: base must be between 2 and 36 (inclusive)")
+	ErrInfiniteNumber = errors.New("numeric: an infinite value cannot be converted to base")
+	ErrNaNNumber      = errors.New("numeric: NaN cannot be converted to base")
+)
+
+const baseDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// ParseBase 将指定进制的字符串转为数值，对应 PHP 源码中的 _php_math_basetozval()
+// - 忽略首尾空白；base 为 16、8、2 时允许可选的 "0x"、"0o"、"0b" 前缀(不区分大小写)
+// - 忽略所有非法字符，此时 invalid 为 true (PHP 7.4 起会产生 Deprecated 提示)
+// - 超出 int64 范围时转为 float 继续计算
+// base 取值为 2~36，否则返回 ErrInvalidBase
+func ParseBase(s string, base int) (v Number, invalid bool, err error) {
+	if base < 2 || base > 36 {
+		return Number{}, false, ErrInvalidBase
+	}
+
+	start, end := 0, len(s)
+	for start < end && ascii.IsSpace(s[start]) {
+		start++
+	}
+	for start < end && ascii.IsSpace(s[end-1]) {
+		end--
+	}
+	if end-start >= 2 && s[start] == '0' {
+		switch {
+		case base == 16 && (s[start+1] == 'x' || s[start+1] == 'X'),
+			base == 8 && (s[start+1] == 'o' || s[start+1] == 'O'),
+			base == 2 && (s[start+1] == 'b' || s[start+1] == 'B'):
+			start += 2
+		}
+	}
+
+	cutoff := int64(math.MaxInt64 / base)
+	cutlim := int64(math.MaxInt64 % base)
+	var num int64
+	var fnum float64
+	isFloat := false
+	for _, c := range []byte(s[start:end]) {
+		d, ok := ascii.ParseDigit(c, base)
+		if !ok {
+			invalid = true
+			continue
+		}
+
+		if !isFloat {
+			if num < cutoff || (num == cutoff && int64(d) <= cutlim) {
+				num = num*int64(base) + int64(d)
+				continue
+			}
+			fnum, isFloat = float64(num), true
+		}
+		fnum = fnum*float64(base) + float64(d)
+	}
+
+	if isFloat {
+		return FloatNumber(fnum), invalid, nil
+	}
+	return IntNumber(num), invalid, nil
+}
+
+// Bindec 二进制字符串转数值，对应 PHP 函数 bindec()
+func Bindec(s string) (v Number, invalid bool) {
+	v, invalid, _ = ParseBase(s, 2)
+	return v, invalid
+}
+
+// Hexdec 十六进制字符串转数值，对应 PHP 函数 hexdec()
+func Hexdec(s string) (v Number, invalid bool) {
+	v, invalid, _ = ParseBase(s, 16)
+	return v, invalid
+}
+
+// Octdec 八进制字符串转数值，对应 PHP 函数 octdec()
+func Octdec(s string) (v Number, invalid bool) {
+	v, invalid, _ = ParseBase(s, 8)
+	return v, invalid
+}
+
+// FormatBase 将数值转为指定进制的字符串，对应 PHP 源码中的 _php_math_zvaltobase()
+// - int 按无符号整数处理，e.g. 负数 -1 的十六进制为 "ffffffffffffffff"
+// - float 先向下取整，±Inf 返回 ErrInfiniteNumber，NaN 返回 ErrNaNNumber；超出 int64 范围的 float 会丢失精度
+// base 取值为 2~36，否则返回 ErrInvalidBase
+func FormatBase(v Number, base int) (string, error) {
+	if base < 2 || base > 36 {
+		return "", ErrInvalidBase
+	}
+	if !v.IsFloat {
+		return strconv.FormatUint(uint64(v.Int), base), nil
+	}
+
+	f := math.Floor(v.Float)
+	if math.IsInf(f, 0) {
+		return "", ErrInfiniteNumber
+	}
+	if math.IsNaN(f) {
+		return "", ErrNaNNumber
+	}
+
+	// 与 PHP 实现一致: 每次除以进制后不取整，最多输出 64 个字符
+	// PHP 中负数 float 为未定义行为，这里按绝对值处理
+	f = math.Abs(f)
+	var buf [64]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = baseDigits[int(math.Mod(f, float64(base)))]
+		f /= float64(base)
+		if i == 0 || math.Abs(f) < 1 {
+			break
+		}
+	}
+	return string(buf[i:]), nil
+}
+
+// BaseConvert 在任意进制之间转换数字字符串，对应 PHP 函数 base_convert()
+// 忽略非法字符(此时 invalid 为 true)；结果超出 int64 范围时按 float 计算，可能丢失精度
+func BaseConvert(s string, fromBase, toBase int) (result string, invalid bool, err error) {
+	if toBase < 2 || toBase > 36 {
+		return "", false, ErrInvalidBase
+	}
+	v, invalid, err := ParseBase(s, fromBase)
+	if err != nil {
+		return "", false, err
+	}
+	result, err = FormatBase(v, toBase)
+	return result, invalid, err
+}
+
+// Decbin 整数转二进制字符串，对应 PHP 函数 decbin()，负数按无符号整数处理
+func Decbin(v int64) string {
+	return strconv.FormatUint(uint64(v), 2)
+}
+
+// Dechex 整数转十六进制字符串，对应 PHP 函数 dechex()，负数按无符号整数处理
+func Dechex(v int64) string {
+	return strconv.FormatUint(uint64(v), 16)
+}
+
+// Decoct 整数转八进制字符串，对应 PHP 函数 decoct()，负数按无符号整数处理
+func Decoct(v int64) string {
+	return strconv.FormatUint(uint64(v), 8)
+}
@@ -0,0 +1,142 @@
+package numeric
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseBase(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		base        int
+		want        Number
+		wantInvalid bool
+		wantErr     error
+	}{
+		{"", "", 16, IntNumber(0), false, nil},
+		{"", "ff", 16, IntNumber(255), false, nil},
+		{"", "FF", 16, IntNumber(255), false, nil},
+		{"", "0xff", 16, IntNumber(255), false, nil},
+		{"", " \t0Xff\n", 16, IntNumber(255), false, nil},
+		{"", "0x", 16, IntNumber(0), false, nil},
+		{"", "gg", 16, IntNumber(0), true, nil},
+		{"", "f-f", 16, IntNumber(255), true, nil},
+		{"", "0b101", 2, IntNumber(5), false, nil},
+		{"", "1012", 2, IntNumber(5), true, nil},
+		{"", "0o777", 8, IntNumber(511), false, nil},
+		{"", "789", 8, IntNumber(7), true, nil},
+		{"", "0xff", 8, IntNumber(0), true, nil},
+		{"", "zz", 36, IntNumber(1295), false, nil},
+		{"", "7fffffffffffffff", 16, IntNumber(math.MaxInt64), false, nil},
+		{"", "8000000000000000", 16, FloatNumber(9223372036854775808), false, nil},
+		{"", "ffffffffffffffff", 16, FloatNumber(18446744073709551615), false, nil},
+		{"", strings.Repeat("1", 64), 2, FloatNumber(18446744073709551615), false, nil},
+		{"", "1", 1, Number{}, false, ErrInvalidBase},
+		{"", "1", 37, Number{}, false, ErrInvalidBase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, invalid, err := ParseBase(tt.s, tt.base)
+			if got != tt.want || invalid != tt.wantInvalid || !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseBase(%q, %d) = %+v, %v, %v, want %+v, %v, %v", tt.s, tt.base, got, invalid, err, tt.want, tt.wantInvalid, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBindecHexdecOctdec(t *testing.T) {
+	if got, _ := Bindec("1111"); got != IntNumber(15) {
+		t.Errorf("Bindec() = %+v", got)
+	}
+	if got, _ := Hexdec("1A"); got != IntNumber(26) {
+		t.Errorf("Hexdec() = %+v", got)
+	}
+	if got, _ := Octdec("777"); got != IntNumber(511) {
+		t.Errorf("Octdec() = %+v", got)
+	}
+}
+
+func TestDecFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       int64
+		wantBin string
+		wantHex string
+		wantOct string
+	}{
+		{"", 0, "0", "0", "0"},
+		{"", 10, "1010", "a", "12"},
+		{"", 255, "11111111", "ff", "377"},
+		{"", math.MaxInt64, strings.Repeat("1", 63), "7fffffffffffffff", "777777777777777777777"},
+		{"", -1, strings.Repeat("1", 64), "ffffffffffffffff", "1777777777777777777777"},
+		{"", math.MinInt64, "1" + strings.Repeat("0", 63), "8000000000000000", "1000000000000000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Decbin(tt.v); got != tt.wantBin {
+				t.Errorf("Decbin(%d) = %v, want %v", tt.v, got, tt.wantBin)
+			}
+			if got := Dechex(tt.v); got != tt.wantHex {
+				t.Errorf("Dechex(%d) = %v, want %v", tt.v, got, tt.wantHex)
+			}
+			if got := Decoct(tt.v); got != tt.wantOct {
+				t.Errorf("Decoct(%d) = %v, want %v", tt.v, got, tt.wantOct)
+			}
+		})
+	}
+}
+
+func TestBaseConvert(t *testing.T) {
+	tests := []struct {
+		name        string
+		s           string
+		from, to    int
+		want        string
+		wantInvalid bool
+		wantErr     error
+	}{
+		{"", "ff", 16, 2, "11111111", false, nil},
+		{"", "A37334", 16, 2, "101000110111001100110100", false, nil},
+		{"", "zz", 36, 10, "1295", false, nil},
+		{"", "-10", 10, 16, "a", true, nil},
+		{"", "", 10, 2, "0", false, nil},
+		{"", "9223372036854775807", 10, 36, "1y2p0ij32e8e7", false, nil},
+		// 超出 int64 范围时按 float 计算
+		{"", "18446744073709551616", 10, 16, "10000000000000000", false, nil},
+		// 与 PHP 一致: float 结果最多输出 64 个字符，2^64 的二进制表示会丢失最高位
+		{"", "18446744073709551616", 10, 2, strings.Repeat("0", 64), false, nil},
+		{"", "1", 1, 10, "", false, ErrInvalidBase},
+		{"", "1", 10, 37, "", false, ErrInvalidBase},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, invalid, err := BaseConvert(tt.s, tt.from, tt.to)
+			if got != tt.want || invalid != tt.wantInvalid || !errors.Is(err, tt.wantErr) {
+				t.Errorf("BaseConvert(%q, %d, %d) = %q, %v, %v, want %q, %v, %v",
+					tt.s, tt.from, tt.to, got, invalid, err, tt.want, tt.wantInvalid, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatBaseNonFinite(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       float64
+		wantErr error
+	}{
+		{"+Inf", math.Inf(1), ErrInfiniteNumber},
+		{"-Inf", math.Inf(-1), ErrInfiniteNumber},
+		{"NaN", math.NaN(), ErrNaNNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := FormatBase(FloatNumber(tt.f), 16); got != "" || !errors.Is(err, tt.wantErr) {
+				t.Errorf("FormatBase(%v) = %q, %v, want %q, %v", tt.f, got, err, "", tt.wantErr)
+			}
+		})
+	}
+}
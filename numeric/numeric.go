@@ -0,0 +1,185 @@
+package numeric
+
+// PHP 数字字符串相关函数
+// 规则与 PHP 8 的 _is_numeric_string_ex() 一致 (参见 RFC: Saner numeric strings)
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"strconv"
+	"strings"
+)
+
+// Kind 数字字符串类型
+type Kind int
+
+const (
+	NonNumeric     Kind = iota // 非数字字符串，e.g. "abc"、""、"."
+	LeadingNumeric             // 前导数字字符串，e.g. "12abc"、"1.5 apples"
+	Numeric                    // 数字字符串，e.g. "12"、" 1e3 "、".5"
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Numeric:
+		return "Numeric"
+	case LeadingNumeric:
+		return "LeadingNumeric"
+	default:
+		return "NonNumeric"
+	}
+}
+
+// Number PHP 数值，为 int 或 float 之一
+type Number struct {
+	IsFloat bool    // 值类型是否为 float；为 false 时值为 Int
+	Int     int64   // IsFloat 为 false 时有效
+	Float   float64 // IsFloat 为 true 时有效
+}
+
+// IntNumber 创建 int 类型数值
+func IntNumber(v int64) Number {
+	return Number{Int: v}
+}
+
+// FloatNumber 创建 float 类型数值
+func FloatNumber(v float64) Number {
+	return Number{IsFloat: true, Float: v}
+}
+
+// Result 数字字符串分析结果
+type Result struct {
+	Kind Kind
+	Number
+	Overflow bool // 是否因整数溢出而提升为 float
+}
+
+// isWhitespace PHP 数字字符串允许的前后空白字符，对应 PHP 源码中的 " \t\n\r\v\f"
+func isWhitespace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// maxIntDigits int64 最大值的十进制位数
+const maxIntDigits = 19
+
+// minIntDigits int64 最小值的绝对值，用于 19 位整数的溢出判断
+const minIntDigits = "9223372036854775808"
+
+// Classify 分析字符串是否为 PHP 数字字符串，并返回其数值
+// 前导数字字符串返回的值为前缀部分的数值；非数字字符串返回零值 Result
+func Classify(s string) Result {
+	// 跳过前导空白
+	start := 0
+	for start < len(s) && isWhitespace(s[start]) {
+		start++
+	}
+
+	i := start
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+
+	var result Result
+	var end int
+	switch {
+	case i < len(s) && ascii.IsDigit(s[i]):
+		// 跳过前导 0 后统计有效数字位数
+		for i < len(s) && s[i] == '0' {
+			i++
+		}
+		digitsStart := i
+		for i < len(s) && ascii.IsDigit(s[i]) {
+			i++
+		}
+		digits := i - digitsStart
+
+		switch {
+		case digits > maxIntDigits:
+			// 超过 19 位必然溢出，整体按浮点数解析
+			result.IsFloat, result.Overflow = true, true
+		case i < len(s) && (s[i] == '.' || (s[i] == 'e' || s[i] == 'E') && hasExponent(s, i)):
+			result.IsFloat = true
+		case digits == maxIntDigits:
+			// 19 位整数需与 |MinInt64| 比较，负数允许相等
+			// 与 PHP 源码一致，使用 strcmp 语义比较(包含数字之后的剩余内容)
+			cmp := strcmp(s[digitsStart:], minIntDigits)
+			if !(cmp < 0 || (cmp == 0 && s[start] == '-')) {
+				result.IsFloat, result.Overflow = true, true
+			}
+		}
+
+		if result.IsFloat {
+			result.Float, end = parseFloatPrefix(s, start)
+		} else {
+			result.Int, _ = strconv.ParseInt(s[start:i], 10, 64)
+			end = i
+		}
+	case i+1 < len(s) && s[i] == '.' && ascii.IsDigit(s[i+1]):
+		result.IsFloat = true
+		result.Float, end = parseFloatPrefix(s, start)
+	default:
+		return Result{}
+	}
+
+	// 允许尾部空白，其他尾部内容视为前导数字字符串
+	for end < len(s) && isWhitespace(s[end]) {
+		end++
+	}
+	if end == len(s) {
+		result.Kind = Numeric
+	} else {
+		result.Kind = LeadingNumeric
+	}
+	return result
+}
+
+// IsNumeric 判断是否为数字字符串，对应 PHP 函数 is_numeric() 对字符串参数的处理
+func IsNumeric(s string) bool {
+	return Classify(s).Kind == Numeric
+}
+
+// strcmp 模拟 C 语言 strcmp()，字符串在首个 NUL 字节处截断
+func strcmp(s1, s2 string) int {
+	if i := strings.IndexByte(s1, 0); i >= 0 {
+		s1 = s1[:i]
+	}
+	return strings.Compare(s1, s2)
+}
+
+// hasExponent 判断 s[i] 处的 'e'/'E' 之后是否为合法的指数部分
+func hasExponent(s string, i int) bool {
+	i++
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	return i < len(s) && ascii.IsDigit(s[i])
+}
+
+// parseFloatPrefix 解析 s[start:] 中的浮点数前缀，返回数值及结束位置；调用方需保证前缀合法
+func parseFloatPrefix(s string, start int) (float64, int) {
+	i := start
+	if s[i] == '-' || s[i] == '+' {
+		i++
+	}
+	for i < len(s) && ascii.IsDigit(s[i]) {
+		i++
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		for i < len(s) && ascii.IsDigit(s[i]) {
+			i++
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') && hasExponent(s, i) {
+		i++
+		if s[i] == '-' || s[i] == '+' {
+			i++
+		}
+		for i < len(s) && ascii.IsDigit(s[i]) {
+			i++
+		}
+	}
+
+	// 超出范围时 strconv 返回 ±Inf，与 PHP 的 zend_strtod() 一致
+	f, _ := strconv.ParseFloat(s[start:i], 64)
+	return f, i
+}
@@ -0,0 +1,101 @@
+package numeric
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	intResult := func(kind Kind, v int64) Result { return Result{Kind: kind, Number: IntNumber(v)} }
+	floatResult := func(kind Kind, v float64) Result { return Result{Kind: kind, Number: FloatNumber(v)} }
+	overflowResult := func(kind Kind, v float64) Result {
+		return Result{Kind: kind, Number: FloatNumber(v), Overflow: true}
+	}
+
+	tests := []struct {
+		name string
+		arg  string
+		want Result
+	}{
+		// 非数字字符串
+		{"", "", Result{}},
+		{"", "abc", Result{}},
+		{"", " ", Result{}},
+		{"", ".", Result{}},
+		{"", "-", Result{}},
+		{"", "+.", Result{}},
+		{"", "e5", Result{}},
+		{"", "0x1A", intResult(LeadingNumeric, 0)},
+		{"", "\x001", Result{}},
+		{"", "- 1", Result{}},
+		// 整数
+		{"", "0", intResult(Numeric, 0)},
+		{"", "12", intResult(Numeric, 12)},
+		{"", "  12", intResult(Numeric, 12)},
+		{"", "12  ", intResult(Numeric, 12)},
+		{"", " \t\n\r\v\f12\f\v\r\n\t ", intResult(Numeric, 12)},
+		{"", "+12", intResult(Numeric, 12)},
+		{"", "-12", intResult(Numeric, -12)},
+		{"", "007", intResult(Numeric, 7)},
+		{"", "12abc", intResult(LeadingNumeric, 12)},
+		{"", "12 abc", intResult(LeadingNumeric, 12)},
+		{"", "12\x00", intResult(LeadingNumeric, 12)},
+		{"", "1e", intResult(LeadingNumeric, 1)},
+		{"", "1e+", intResult(LeadingNumeric, 1)},
+		// 浮点数
+		{"", "1.", floatResult(Numeric, 1)},
+		{"", ".5", floatResult(Numeric, 0.5)},
+		{"", "-.5", floatResult(Numeric, -0.5)},
+		{"", "1.5", floatResult(Numeric, 1.5)},
+		{"", "1e3", floatResult(Numeric, 1000)},
+		{"", "1e3 ", floatResult(Numeric, 1000)},
+		{"", "1E-3", floatResult(Numeric, 0.001)},
+		{"", "1.5e+3", floatResult(Numeric, 1500)},
+		{"", "1.5 apples", floatResult(LeadingNumeric, 1.5)},
+		{"", "1e999", floatResult(Numeric, math.Inf(1))},
+		{"", "-1e999", floatResult(Numeric, math.Inf(-1))},
+		// int64 边界及溢出
+		{"", "9223372036854775807", intResult(Numeric, math.MaxInt64)},
+		{"", "-9223372036854775808", intResult(Numeric, math.MinInt64)},
+		{"", "0009223372036854775807", intResult(Numeric, math.MaxInt64)},
+		{"", "9223372036854775808", overflowResult(Numeric, 9223372036854775808)},
+		{"", "-9223372036854775809", overflowResult(Numeric, -9223372036854775809)},
+		{"", "99999999999999999999", overflowResult(Numeric, 1e20)},
+		{"", "123456789012345678901.5", overflowResult(Numeric, 123456789012345678901.5)},
+		{"", "9223372036854775807abc", intResult(LeadingNumeric, math.MaxInt64)},
+		// 与 PHP 一致: 19 位时按 strcmp 比较剩余内容，尾部内容会导致 MinInt64 溢出为 float
+		{"", "-9223372036854775808 ", overflowResult(Numeric, -9223372036854775808)},
+		{"", "-9223372036854775808\x00", intResult(LeadingNumeric, math.MinInt64)},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Quote(tt.arg), func(t *testing.T) {
+			if got := Classify(tt.arg); got != tt.want {
+				t.Errorf("Classify(%q) = %+v, want %+v", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNumeric(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want bool
+	}{
+		{"", "", false},
+		{"", "42", true},
+		{"", " 42 ", true},
+		{"", "4.2e1", true},
+		{"", "42abc", false},
+		{"", "0x2A", false},
+		{"", "abc", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNumeric(tt.arg); got != tt.want {
+				t.Errorf("IsNumeric(%q) = %v, want %v", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
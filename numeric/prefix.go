@@ -0,0 +1,171 @@
+package numeric
+
+// 本文件内是 strtol/strtod 风格的前缀数字解析函数，用于词法分析等需要解析较长输入前缀的场景。
+// 支持 PHP 7.4 起的数字分隔符 '_'，'_' 只能出现在两个数字之间，e.g. "1_000"、"0x_1" 中的 '_' 不合法。
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// isDigit 判断是否为指定进制下的数字
+func isDigit(c byte, base int) bool {
+	_, ok := ascii.ParseDigit(c, base)
+	return ok
+}
+
+// detectBase 检测进制前缀，返回进制及前缀长度
+// base 为 0 时自动检测: "0x"/"0X" 为 16 进制，"0b"/"0B" 为 2 进制，"0o"/"0O" 及其他以 "0" 开头的为 8 进制，其他为 10 进制
+// base 为 16、2、8 时允许对应的可选前缀
+// 前缀之后没有合法数字时不视为前缀，e.g. "0x" 按 "0" 解析
+func detectBase(s string, base int) (int, int) {
+	if len(s) >= 3 && s[0] == '0' {
+		prefixBase := 0
+		switch s[1] {
+		case 'x', 'X':
+			prefixBase = 16
+		case 'b', 'B':
+			prefixBase = 2
+		case 'o', 'O':
+			prefixBase = 8
+		}
+		if prefixBase != 0 && (base == 0 || base == prefixBase) && isDigit(s[2], prefixBase) {
+			return prefixBase, 2
+		}
+	}
+	if base == 0 {
+		if len(s) >= 1 && s[0] == '0' {
+			return 8, 0
+		}
+		return 10, 0
+	}
+	return base, 0
+}
+
+// scanDigits 扫描 s[i:] 中指定进制的数字(允许 '_' 分隔符)，返回结束位置
+func scanDigits(s string, i int, base int) int {
+	for ; i < len(s); i++ {
+		if s[i] == '_' {
+			// '_' 必须位于两个数字之间
+			if i+1 < len(s) && isDigit(s[i+1], base) {
+				continue
+			}
+			break
+		}
+		if !isDigit(s[i], base) {
+			break
+		}
+	}
+	return i
+}
+
+// ParseIntPrefix 解析字符串前缀中的整数，返回数值、消耗的字节数及是否溢出
+// - base 取值为 0 或 2~36，为 0 时根据前缀自动检测进制(参见 detectBase)；其他取值时不解析，返回 0, 0, false
+// - 允许可选的正负号，不跳过前导空白
+// - 允许数字间的 '_' 分隔符
+// - 溢出时返回 math.MaxInt64 或 math.MinInt64，并继续消耗后续数字
+// - 没有合法数字时返回 0, 0, false
+// 不会分配内存
+func ParseIntPrefix(s string, base int) (v int64, n int, overflow bool) {
+	if base != 0 && (base < 2 || base > 36) {
+		return 0, 0, false
+	}
+
+	i := 0
+	neg := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+	base, prefixLen := detectBase(s[i:], base)
+	i += prefixLen
+	if i >= len(s) || !isDigit(s[i], base) {
+		return 0, 0, false
+	}
+
+	// 以负数累加，可以表示 math.MinInt64
+	var acc int64
+	limit := int64(math.MinInt64) / int64(base)
+	end := scanDigits(s, i, base)
+	for ; i < end; i++ {
+		if s[i] == '_' {
+			continue
+		}
+		digit, _ := ascii.ParseDigit(s[i], base)
+		d := int64(digit)
+		if acc < limit || acc*int64(base) < math.MinInt64+d {
+			overflow = true
+			break
+		}
+		acc = acc*int64(base) - d
+	}
+
+	switch {
+	case overflow && neg:
+		return math.MinInt64, end, true
+	case overflow || (!neg && acc == math.MinInt64):
+		return math.MaxInt64, end, true
+	case neg:
+		return acc, end, false
+	default:
+		return -acc, end, false
+	}
+}
+
+// floatBufferSize 含 '_' 分隔符的浮点数需复制到栈上缓冲区后解析
+const floatBufferSize = 256
+
+// ParseFloatPrefix 解析字符串前缀中的十进制浮点数，返回数值、消耗的字节数及是否溢出
+// - 格式与 PHP 浮点数字面量一致: [+-]digits[.digits][(e|E)[+-]digits]，整数部分和小数部分不能同时为空
+// - 允许数字间的 '_' 分隔符；不支持 "inf"、"nan" 及十六进制浮点数
+// - 溢出时返回 ±Inf
+// - 没有合法数字时返回 0, 0, false
+// 除超长(超过 256 字节)且含 '_' 分隔符的输入外，不会分配内存
+func ParseFloatPrefix(s string) (v float64, n int, overflow bool) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	digitsStart := i
+	i = scanDigits(s, i, 10)
+	hasIntPart := i > digitsStart
+	if i+1 < len(s) && s[i] == '.' && ascii.IsDigit(s[i+1]) {
+		i = scanDigits(s, i+1, 10)
+	} else if i < len(s) && s[i] == '.' && hasIntPart {
+		i++ // "1." 形式
+	} else if !hasIntPart {
+		return 0, 0, false
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && ascii.IsDigit(s[j]) {
+			i = scanDigits(s, j, 10)
+		}
+	}
+
+	literal := s[:i]
+	if strings.IndexByte(literal, '_') >= 0 {
+		// strconv.ParseFloat 不支持十进制中的 '_'，去除后再解析
+		var buf [floatBufferSize]byte
+		stripped := buf[:0]
+		if len(literal) > len(buf) {
+			stripped = make([]byte, 0, len(literal))
+		}
+		for k := 0; k < len(literal); k++ {
+			if literal[k] != '_' {
+				stripped = append(stripped, literal[k])
+			}
+		}
+		literal = unsafe.String(unsafe.SliceData(stripped), len(stripped))
+	}
+
+	// 格式已预先校验，只可能返回溢出错误
+	v, _ = strconv.ParseFloat(literal, 64)
+	return v, i, math.IsInf(v, 0)
+}
@@ -0,0 +1,123 @@
+package numeric
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseIntPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		s            string
+		base         int
+		want         int64
+		wantN        int
+		wantOverflow bool
+	}{
+		{"", "", 10, 0, 0, false},
+		{"", "abc", 10, 0, 0, false},
+		{"", "-", 10, 0, 0, false},
+		{"", "123abc", 10, 123, 3, false},
+		{"", "-123", 10, -123, 4, false},
+		{"", "+123", 10, 123, 4, false},
+		{"", " 123", 10, 0, 0, false},
+		{"", "1_000_000;", 10, 1000000, 9, false},
+		{"", "1__0", 10, 1, 1, false},
+		{"", "1_", 10, 1, 1, false},
+		{"", "_1", 10, 0, 0, false},
+		// 自动检测进制
+		{"", "0x1A", 0, 26, 4, false},
+		{"", "0XfF_fF", 0, 0xffff, 7, false},
+		{"", "0b1010", 0, 10, 6, false},
+		{"", "0o17", 0, 15, 4, false},
+		{"", "017", 0, 15, 3, false},
+		{"", "0_17", 0, 15, 4, false},
+		{"", "089", 0, 0, 1, false},
+		{"", "0x", 0, 0, 1, false},
+		{"", "0x_1", 0, 0, 1, false},
+		{"", "0xg", 0, 0, 1, false},
+		{"", "0b2", 0, 0, 1, false},
+		{"", "-0x10", 0, -16, 5, false},
+		{"", "42", 0, 42, 2, false},
+		// 指定进制
+		{"", "0x1A", 16, 26, 4, false},
+		{"", "1A", 16, 26, 2, false},
+		{"", "0x1A", 10, 0, 1, false},
+		{"", "zz", 36, 1295, 2, false},
+		{"", "0b11", 2, 3, 4, false},
+		{"", "12", 2, 1, 1, false},
+		{"", "1", 1, 0, 0, false},
+		{"", "1", 37, 0, 0, false},
+		// 边界与溢出
+		{"", "9223372036854775807", 10, math.MaxInt64, 19, false},
+		{"", "-9223372036854775808", 10, math.MinInt64, 20, false},
+		{"", "9223372036854775808", 10, math.MaxInt64, 19, true},
+		{"", "-9223372036854775809", 10, math.MinInt64, 20, true},
+		{"", "99999999999999999999999x", 10, math.MaxInt64, 23, true},
+		{"", "0x7fffffffffffffff", 0, math.MaxInt64, 18, false},
+		{"", "0x8000000000000000", 0, math.MaxInt64, 18, true},
+		{"", "-0x8000000000000000", 0, math.MinInt64, 19, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, n, overflow := ParseIntPrefix(tt.s, tt.base)
+			if got != tt.want || n != tt.wantN || overflow != tt.wantOverflow {
+				t.Errorf("ParseIntPrefix(%q, %d) = %v, %v, %v, want %v, %v, %v",
+					tt.s, tt.base, got, n, overflow, tt.want, tt.wantN, tt.wantOverflow)
+			}
+		})
+	}
+}
+
+func TestParseFloatPrefix(t *testing.T) {
+	tests := []struct {
+		name         string
+		s            string
+		want         float64
+		wantN        int
+		wantOverflow bool
+	}{
+		{"", "", 0, 0, false},
+		{"", ".", 0, 0, false},
+		{"", "-.", 0, 0, false},
+		{"", "e5", 0, 0, false},
+		{"", "inf", 0, 0, false},
+		{"", "1", 1, 1, false},
+		{"", "1.5;", 1.5, 3, false},
+		{"", "1.", 1, 2, false},
+		{"", ".5", 0.5, 2, false},
+		{"", "-.5", -0.5, 3, false},
+		{"", "1e3", 1000, 3, false},
+		{"", "1.e3", 1000, 4, false},
+		{"", "1E-3x", 0.001, 4, false},
+		{"", "1e", 1, 1, false},
+		{"", "1e+", 1, 1, false},
+		{"", "1_000.000_5", 1000.0005, 11, false},
+		{"", "1_000e1_0", 1000e10, 9, false},
+		{"", "1_.5", 1, 1, false},
+		{"", "1e999", math.Inf(1), 5, true},
+		{"", "-1e999", math.Inf(-1), 6, true},
+		{"", "1e-999", 0, 6, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, n, overflow := ParseFloatPrefix(tt.s)
+			if got != tt.want || n != tt.wantN || overflow != tt.wantOverflow {
+				t.Errorf("ParseFloatPrefix(%q) = %v, %v, %v, want %v, %v, %v",
+					tt.s, got, n, overflow, tt.want, tt.wantN, tt.wantOverflow)
+			}
+		})
+	}
+}
+
+func TestParsePrefixNoAlloc(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		ParseIntPrefix("0x7fff_ffff;", 0)
+		ParseIntPrefix("99999999999999999999999", 10)
+		ParseFloatPrefix("1_000.000_5e1_0;")
+		ParseFloatPrefix("3.141592653589793")
+	})
+	if allocs != 0 {
+		t.Errorf("allocs = %v, want 0", allocs)
+	}
+}
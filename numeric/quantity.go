@@ -0,0 +1,264 @@
+package numeric
+
+// 本文件内是 ini 配置中带单位的数量解析函数，与 PHP 8.2 的 ini_parse_quantity() 一致
+// e.g. "128M"、"1g"、"0x10K"、" -1"
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// QuantityWarningKind 数量解析警告类型
+type QuantityWarningKind int
+
+const (
+	QuantityNoDigits            QuantityWarningKind = iota + 1 // 没有合法的前导数字，e.g. "abc"、"-"
+	QuantityInvalidPrefix                                      // 非法的进制前缀，e.g. "0z1"
+	QuantityNoDigitsAfterPrefix                                // 进制前缀之后没有数字，e.g. "0x"、"0x-1"
+	QuantityUnknownMultiplier                                  // 未知的单位，e.g. "128B"
+	QuantityTrailingGarbage                                    // 数字与单位之间有多余字符，e.g. "128MK"
+	QuantityOutOfRange                                         // 超出取值范围，e.g. "9999999999G"
+)
+
+// QuantityWarning 数量解析警告，PHP 中此时产生 E_WARNING 但仍返回解析结果
+type QuantityWarning struct {
+	Kind        QuantityWarningKind
+	Value       string // 原始字符串
+	Interpreted string // 实际解释的内容，仅 QuantityUnknownMultiplier、QuantityTrailingGarbage 时有效
+	Multiplier  byte   // 末尾字符，仅 QuantityUnknownMultiplier、QuantityTrailingGarbage 时有效
+	Prefix      byte   // 非法的进制前缀字符，仅 QuantityInvalidPrefix 时有效
+}
+
+// Error 返回与 PHP 一致的警告信息
+func (w *QuantityWarning) Error() string {
+	value := escapeQuantity(w.Value)
+	switch w.Kind {
+	case QuantityNoDigits:
+		return `Invalid quantity "` + value + `": no valid leading digits, interpreting as "0" for backwards compatibility`
+	case QuantityInvalidPrefix:
+		return `Invalid prefix "0` + string([]byte{w.Prefix}) + `", interpreting as "0" for backwards compatibility`
+	case QuantityNoDigitsAfterPrefix:
+		return `Invalid quantity "` + value + `": no digits after base prefix, interpreting as "0" for backwards compatibility`
+	case QuantityUnknownMultiplier:
+		return `Invalid quantity "` + value + `": unknown multiplier "` + escapeQuantity(string([]byte{w.Multiplier})) +
+			`", interpreting as "` + escapeQuantity(w.Interpreted) + `" for backwards compatibility`
+	case QuantityTrailingGarbage:
+		return `Invalid quantity "` + value + `", interpreting as "` + escapeQuantity(w.Interpreted) +
+			escapeQuantity(string([]byte{w.Multiplier})) + `" for backwards compatibility`
+	case QuantityOutOfRange:
+		return `Invalid quantity "` + value + `": value is out of range, using overflow result for backwards compatibility`
+	default:
+		return `Invalid quantity "` + value + `"`
+	}
+}
+
+// escapeQuantity 转义不可打印字符，对应 PHP 源码中的 smart_str_append_escaped()
+func escapeQuantity(s string) string {
+	var buf strings.Builder
+	for _, c := range []byte(s) {
+		if c >= 32 && c <= 126 && c != '\\' {
+			buf.WriteByte(c)
+			continue
+		}
+		buf.WriteByte('\\')
+		switch c {
+		case '\n':
+			buf.WriteByte('n')
+		case '\r':
+			buf.WriteByte('r')
+		case '\t':
+			buf.WriteByte('t')
+		case '\f':
+			buf.WriteByte('f')
+		case '\v':
+			buf.WriteByte('v')
+		case '\\':
+			buf.WriteByte('\\')
+		case 0x1b:
+			buf.WriteByte('e')
+		default:
+			buf.WriteByte('x')
+			buf.WriteByte("0123456789ABCDEF"[c>>4])
+			buf.WriteByte("0123456789ABCDEF"[c&0xf])
+		}
+	}
+	return buf.String()
+}
+
+// ParseQuantity 解析带单位的数量，对应 PHP 8.2 函数 ini_parse_quantity()
+// - 忽略首尾空白，允许可选的正负号及 "0x"、"0o"、"0b" 进制前缀(以 0 开头的其他数字按八进制处理，e.g. "010" 为 8)
+// - 支持不区分大小写的单位后缀 K(1<<10)、M(1<<20)、G(1<<30)，数字与单位之间允许空白
+// - 格式不合法时返回 *QuantityWarning，v 为 PHP 的兼容性解析结果；溢出时 v 为按补码回绕的结果
+// - 空字符串返回 0
+func ParseQuantity(s string) (v int64, warn *QuantityWarning) {
+	r, warn := parseQuantity(s, true)
+	return int64(r), warn
+}
+
+// ParseQuantityUnsigned 解析带单位的无符号数量，对应 PHP 源码中的 zend_ini_parse_uquantity()
+// 规则与 ParseQuantity 相同，但负数视为溢出，仅 "-1" 例外(返回 math.MaxUint64，常用于表示无限制，e.g. memory_limit=-1)
+func ParseQuantityUnsigned(s string) (v uint64, warn *QuantityWarning) {
+	return parseQuantity(s, false)
+}
+
+// parseQuantity 对应 PHP 源码中的 zend_ini_parse_quantity_internal()
+func parseQuantity(s string, signed bool) (uint64, *QuantityWarning) {
+	start, end := 0, len(s)
+	for start < end && ascii.IsSpace(s[start]) {
+		start++
+	}
+	for start < end && ascii.IsSpace(s[end-1]) {
+		end--
+	}
+	if start == end {
+		return 0, nil
+	}
+
+	i := start
+	negative := false
+	if s[i] == '+' || s[i] == '-' {
+		negative = s[i] == '-'
+		i++
+	}
+	if i >= end || !ascii.IsDigit(s[i]) {
+		return 0, &QuantityWarning{Kind: QuantityNoDigits, Value: s}
+	}
+
+	// 与 PHP 一致，未匹配进制前缀时按 strtoull() 的 base 0 规则解析: 以 0 开头为八进制，否则为十进制
+	base := 10
+	if s[i] == '0' {
+		base = 8
+	}
+	if s[i] == '0' && (i+1 >= end || !ascii.IsDigit(s[i+1])) {
+		if i+1 == end {
+			return 0, nil
+		}
+		switch s[i+1] {
+		case 'g', 'G', 'm', 'M', 'k', 'K':
+			// "0K" 等形式，按 0 处理单位
+		case 'x', 'X', 'o', 'O', 'b', 'B':
+			switch s[i+1] {
+			case 'x', 'X':
+				base = 16
+			case 'o', 'O':
+				base = 8
+			default:
+				base = 2
+			}
+			i += 2
+			if i == end || !ascii.IsAlphaNum(s[i]) {
+				return 0, &QuantityWarning{Kind: QuantityNoDigitsAfterPrefix, Value: s}
+			}
+		default:
+			return 0, &QuantityWarning{Kind: QuantityInvalidPrefix, Value: s, Prefix: s[i+1]}
+		}
+	}
+
+	r, digitsEnd, overflow := strtoul(s[:end], i, base)
+	if digitsEnd == i {
+		return 0, &QuantityWarning{Kind: QuantityNoDigits, Value: s}
+	}
+	if !overflow {
+		switch {
+		case !signed && negative:
+			// "-1" 常用于表示最大值
+			if r == 1 && digitsEnd == end {
+				r = math.MaxUint64
+			} else {
+				overflow = true
+			}
+		case signed && negative && r == 1<<63:
+			// math.MinInt64
+		case signed && r >= 1<<63:
+			overflow = true
+		case signed && negative:
+			r = -r
+		}
+	}
+
+	// 允许数字与单位之间的空白
+	for digitsEnd < end && ascii.IsSpace(s[digitsEnd]) {
+		digitsEnd++
+	}
+	if digitsEnd < end {
+		var shift uint
+		switch s[end-1] {
+		case 'g', 'G':
+			shift = 30
+		case 'm', 'M':
+			shift = 20
+		case 'k', 'K':
+			shift = 10
+		default:
+			return r, &QuantityWarning{Kind: QuantityUnknownMultiplier, Value: s, Interpreted: s[:digitsEnd], Multiplier: s[end-1]}
+		}
+		if digitsEnd < end-1 {
+			return r << shift, &QuantityWarning{Kind: QuantityTrailingGarbage, Value: s, Interpreted: s[:digitsEnd], Multiplier: s[end-1]}
+		}
+
+		if !overflow {
+			if signed {
+				sr := int64(r)
+				overflow = sr > math.MaxInt64>>shift || sr < math.MinInt64>>shift
+			} else {
+				overflow = r > math.MaxUint64>>shift
+			}
+		}
+		r <<= shift
+	}
+
+	if overflow {
+		return r, &QuantityWarning{Kind: QuantityOutOfRange, Value: s}
+	}
+	return r, nil
+}
+
+// strtoul 模拟 C 语言 strtoull()，从 s[i:] 解析指定进制的无符号整数，返回数值、结束位置及是否溢出
+// 溢出时返回 math.MaxUint64 并继续消耗后续数字；没有数字时结束位置为 i
+func strtoul(s string, i int, base int) (v uint64, end int, overflow bool) {
+	start := i
+	// base 为 16 时 strtoull() 允许可选的 "0x" 前缀
+	if base == 16 && i+2 < len(s) && s[i] == '0' && (s[i+1] == 'x' || s[i+1] == 'X') && ascii.IsXDigit(s[i+2]) {
+		i += 2
+	}
+	for ; i < len(s); i++ {
+		d, ok := ascii.ParseDigit(s[i], base)
+		if !ok {
+			break
+		}
+		if overflow {
+			continue
+		}
+		hi, lo := bits.Mul64(v, uint64(base))
+		lo, carry := bits.Add64(lo, uint64(d), 0)
+		if hi != 0 || carry != 0 {
+			v, overflow = math.MaxUint64, true
+			continue
+		}
+		v = lo
+	}
+	if i == start {
+		return 0, start, false
+	}
+	return v, i, overflow
+}
+
+// FormatQuantity 将数量格式化为带单位的字符串，是 ParseQuantity 的逆运算
+// 使用能精确表示数值的最大单位，e.g. 134217728 => "128M"、1536 => "1536"
+func FormatQuantity(v int64) string {
+	if v == 0 {
+		return "0"
+	}
+	for _, unit := range [...]struct {
+		shift  uint
+		suffix string
+	}{{30, "G"}, {20, "M"}, {10, "K"}} {
+		if v&(1<<unit.shift-1) == 0 {
+			return strconv.FormatInt(v>>unit.shift, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(v, 10)
+}
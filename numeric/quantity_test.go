@@ -0,0 +1,164 @@
+package numeric
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		s        string
+		want     int64
+		wantWarn QuantityWarningKind // 0 表示无警告
+	}{
+		{"", 0, 0},
+		{"  ", 0, 0},
+		{"0", 0, 0},
+		{"128", 128, 0},
+		{"128M", 128 << 20, 0},
+		{"128m", 128 << 20, 0},
+		{"1g", 1 << 30, 0},
+		{"1G", 1 << 30, 0},
+		{"2k", 2048, 0},
+		{" 2 K ", 2048, 0},
+		{"\t-1\n", -1, 0},
+		{"+1K", 1024, 0},
+		{"-1K", -1024, 0},
+		{"0K", 0, 0},
+		{"010", 8, 0},
+		{"01024", 532, 0},
+		{"-017K", -15 << 10, 0},
+		{"00x1", 0, QuantityUnknownMultiplier},
+		{"0x10", 16, 0},
+		{"0x10K", 16 << 10, 0},
+		{"0XfF", 255, 0},
+		{"0o17", 15, 0},
+		{"0b101", 5, 0},
+		{"-0x10", -16, 0},
+		{"0x0x10", 16, 0},
+		{"9223372036854775807", math.MaxInt64, 0},
+		{"-9223372036854775808", math.MinInt64, 0},
+		{"8589934591G", 8589934591 << 30, 0},
+		{"-8589934592G", math.MinInt64, 0},
+
+		{"abc", 0, QuantityNoDigits},
+		{"-", 0, QuantityNoDigits},
+		{"- 1", 0, QuantityNoDigits},
+		{"M", 0, QuantityNoDigits},
+		{"0xg", 0, QuantityNoDigits},
+		{"0b2", 0, QuantityNoDigits},
+		{"0z1", 0, QuantityInvalidPrefix},
+		{"0 K", 0, QuantityInvalidPrefix},
+		{"0 1", 0, QuantityInvalidPrefix},
+		{"-0 1", 0, QuantityInvalidPrefix},
+		{"0x", 0, QuantityNoDigitsAfterPrefix},
+		{"0x-1", 0, QuantityNoDigitsAfterPrefix},
+		{"0x 1", 0, QuantityNoDigitsAfterPrefix},
+		{"128B", 128, QuantityUnknownMultiplier},
+		{"128 apples", 128, QuantityUnknownMultiplier},
+		{"09", 0, QuantityUnknownMultiplier},
+		{"0778", 63, QuantityUnknownMultiplier},
+		{"1.5M", 1 << 20, QuantityTrailingGarbage},
+		{"128MK", 128 << 10, QuantityTrailingGarbage},
+		{"1 foo G", 1 << 30, QuantityTrailingGarbage},
+		{"9223372036854775808", math.MinInt64, QuantityOutOfRange},
+		{"-9223372036854775809", -9223372036854775807, QuantityOutOfRange},
+		{"99999999999999999999", -1, QuantityOutOfRange},
+		{"8589934592G", math.MinInt64, QuantityOutOfRange},
+		{"-8589934593G", 0x7fffffffc0000000, QuantityOutOfRange},
+	}
+	for _, tt := range tests {
+		got, warn := ParseQuantity(tt.s)
+		var gotWarn QuantityWarningKind
+		if warn != nil {
+			gotWarn = warn.Kind
+		}
+		if got != tt.want || gotWarn != tt.wantWarn {
+			t.Errorf("ParseQuantity(%q) = %d, %v, want %d, %v", tt.s, got, warn, tt.want, tt.wantWarn)
+		}
+	}
+}
+
+func TestParseQuantityUnsigned(t *testing.T) {
+	tests := []struct {
+		s        string
+		want     uint64
+		wantWarn QuantityWarningKind
+	}{
+		{"128M", 128 << 20, 0},
+		{"-1", math.MaxUint64, 0},
+		{" -1 ", math.MaxUint64, 0},
+		{"18446744073709551615", math.MaxUint64, 0},
+		{"17179869183G", 17179869183 << 30, 0},
+		{"-2", 2, QuantityOutOfRange},
+		{"-1K", 1024, QuantityOutOfRange},
+		{"18446744073709551616", math.MaxUint64, QuantityOutOfRange},
+		{"17179869184G", 0, QuantityOutOfRange},
+	}
+	for _, tt := range tests {
+		got, warn := ParseQuantityUnsigned(tt.s)
+		var gotWarn QuantityWarningKind
+		if warn != nil {
+			gotWarn = warn.Kind
+		}
+		if got != tt.want || gotWarn != tt.wantWarn {
+			t.Errorf("ParseQuantityUnsigned(%q) = %d, %v, want %d, %v", tt.s, got, warn, tt.want, tt.wantWarn)
+		}
+	}
+}
+
+func TestQuantityWarningError(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"abc", `Invalid quantity "abc": no valid leading digits, interpreting as "0" for backwards compatibility`},
+		{"0z1", `Invalid prefix "0z", interpreting as "0" for backwards compatibility`},
+		{"0x", `Invalid quantity "0x": no digits after base prefix, interpreting as "0" for backwards compatibility`},
+		{"128B", `Invalid quantity "128B": unknown multiplier "B", interpreting as "128" for backwards compatibility`},
+		{" 128 MB", `Invalid quantity " 128 MB": unknown multiplier "B", interpreting as " 128 " for backwards compatibility`},
+		{"128MK", `Invalid quantity "128MK", interpreting as "128K" for backwards compatibility`},
+		{"1\x00K", `Invalid quantity "1\x00K", interpreting as "1K" for backwards compatibility`},
+		// 非 ASCII 字节按单字节处理
+		{"128\xff", `Invalid quantity "128\xFF": unknown multiplier "\xFF", interpreting as "128" for backwards compatibility`},
+		{"0\xff", "Invalid prefix \"0\xff\", interpreting as \"0\" for backwards compatibility"},
+		{"x\n\\", `Invalid quantity "x\n\\": no valid leading digits, interpreting as "0" for backwards compatibility`},
+		{"99999999999999999999", `Invalid quantity "99999999999999999999": value is out of range, using overflow result for backwards compatibility`},
+	}
+	for _, tt := range tests {
+		_, warn := ParseQuantity(tt.s)
+		if warn == nil || warn.Error() != tt.want {
+			t.Errorf("ParseQuantity(%q) warning = %v, want %v", tt.s, warn, tt.want)
+		}
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	tests := []struct {
+		v    int64
+		want string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{-1, "-1"},
+		{1000, "1000"},
+		{1024, "1K"},
+		{1536, "1536"},
+		{3 << 19, "1536K"},
+		{128 << 20, "128M"},
+		{-(128 << 20), "-128M"},
+		{1 << 30, "1G"},
+		{(1 << 30) + 1024, "1048577K"},
+		{math.MaxInt64, "9223372036854775807"},
+		{math.MinInt64, "-8589934592G"},
+	}
+	for _, tt := range tests {
+		got := FormatQuantity(tt.v)
+		if got != tt.want {
+			t.Errorf("FormatQuantity(%d) = %v, want %v", tt.v, got, tt.want)
+		}
+		if back, warn := ParseQuantity(got); back != tt.v || warn != nil {
+			t.Errorf("ParseQuantity(FormatQuantity(%d)) = %d, %v", tt.v, back, warn)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package numfmt
+
+import (
+	"github.com/heyuuu/gophp-utils/xmath"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat 以千位分隔符格式化数字，对应 PHP 函数 number_format()
+// - 先按 PHP_ROUND_HALF_UP 模式舍入到 decimals 位小数；decimals 为负数时舍入到整数位(PHP 8.3 起)，e.g. (1234.5, -2) => "1,200"
+// - decPoint、thousandsSep 可以为空字符串或多字节字符串
+// - 舍入后为 0 的负数不输出负号，e.g. -0.4 => "0"
+// - 无穷大及 NaN 输出 "INF"、"NAN" (不含负号)，与 PHP 一致
+func NumberFormat(num float64, decimals int, decPoint, thousandsSep string) string {
+	negative := false
+	if num < 0 {
+		negative = true
+		num = -num
+	}
+
+	num = xmath.Round(num, decimals, xmath.RoundHalfUp)
+	decimals = max(decimals, 0)
+	if math.IsInf(num, 0) {
+		return "INF"
+	} else if math.IsNaN(num) {
+		return "NAN"
+	}
+	if negative && num == 0 {
+		negative = false
+	}
+
+	tmp := strconv.FormatFloat(num, 'f', decimals, 64)
+	intPart, fracPart, _ := strings.Cut(tmp, ".")
+
+	var buf strings.Builder
+	buf.Grow(len(tmp) + 1 + len(thousandsSep)*(len(intPart)/3) + len(decPoint))
+	if negative {
+		buf.WriteByte('-')
+	}
+	for i := 0; i < len(intPart); i++ {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			buf.WriteString(thousandsSep)
+		}
+		buf.WriteByte(intPart[i])
+	}
+	if decimals > 0 {
+		buf.WriteString(decPoint)
+		buf.WriteString(fracPart)
+	}
+	return buf.String()
+}
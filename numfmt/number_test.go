@@ -0,0 +1,59 @@
+package numfmt
+
+import (
+	"math"
+	"testing"
+)
+
+// 以下期望值均为 PHP 8.4 的输出
+
+func TestNumberFormat(t *testing.T) {
+	tests := []struct {
+		num          float64
+		decimals     int
+		decPoint     string
+		thousandsSep string
+		want         string
+	}{
+		{0, 0, ".", ",", "0"},
+		{1234.5678, 0, ".", ",", "1,235"},
+		{1234.5678, 2, ".", ",", "1,234.57"},
+		{1234.5678, 2, ",", ".", "1.234,57"},
+		{1234.5678, 2, ".", " ", "1 234.57"},
+		{1234.5678, 2, ".", "", "1234.57"},
+		{1234.5678, 2, "", ",", "1,23457"},
+		{1234567.891, 2, "٫", " ", "1 234 567٫89"},
+		{-1234.567, 2, ".", ",", "-1,234.57"},
+		{-1234.567, 0, ".", ",", "-1,235"},
+		{123, 0, ".", ",", "123"},
+		{123456, 0, ".", ",", "123,456"},
+		{1234567, 3, ".", ",", "1,234,567.000"},
+		{0.5, 0, ".", ",", "1"},
+		{1.5, 0, ".", ",", "2"},
+		{2.5, 0, ".", ",", "3"},
+		{-2.5, 0, ".", ",", "-3"},
+		{0.285, 2, ".", ",", "0.29"},
+		{1.005, 2, ".", ",", "1.01"},
+		{1.955, 2, ".", ",", "1.96"},
+		{5.055, 2, ".", ",", "5.06"},
+		{0.49999999999999994, 0, ".", ",", "0"},
+		{-0.4, 0, ".", ",", "0"},
+		{-0.001, 2, ".", ",", "0.00"},
+		{1234.5, -2, ".", ",", "1,200"},
+		{1250, -2, ".", ",", "1,300"},
+		{-1250, -2, ".", ",", "-1,300"},
+		{1234.5, -5, ".", ",", "0"},
+		{1e15, 2, ".", ",", "1,000,000,000,000,000.00"},
+		{1e20, 0, ".", ",", "100,000,000,000,000,000,000"},
+		{float64(math.MaxInt64), 0, ".", ",", "9,223,372,036,854,775,808"},
+		{pointThree, 20, ".", ",", "0.30000000000000004441"},
+		{math.Inf(1), 2, ".", ",", "INF"},
+		{math.Inf(-1), 2, ".", ",", "INF"},
+		{math.NaN(), 2, ".", ",", "NAN"},
+	}
+	for _, tt := range tests {
+		if got := NumberFormat(tt.num, tt.decimals, tt.decPoint, tt.thousandsSep); got != tt.want {
+			t.Errorf("NumberFormat(%v, %d, %q, %q) = %q, want %q", tt.num, tt.decimals, tt.decPoint, tt.thousandsSep, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package numfmt
+
+// PHP 数值格式化相关函数
+// Go 标准库 strconv 的输出格式在很多边界情况下与 PHP 不同(e.g. 科学计数法的阈值及格式、-0、INF/NAN)，本包按 PHP 源码逻辑实现
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPrecision php.ini 中 precision 的默认值，用于 echo、字符串转换等
+	DefaultPrecision = 14
+	// SerializePrecision php.ini 中 serialize_precision 的默认值，-1 表示使用最短的可往返表示，用于 var_export、json_encode、serialize 等
+	SerializePrecision = -1
+)
+
+// decimalDigits 将浮点数转为十进制有效数字，返回不含末尾 0 的数字串及小数点位置，对应 zend_dtoa()
+// precision > 0 时保留 precision 位有效数字(四舍六入五成双，基于精确二进制值)，否则返回最短的可往返表示
+// f 必须为有限值，返回的 digits 不含符号
+func decimalDigits(f float64, precision int) (digits string, decpt int) {
+	if f == 0 {
+		return "0", 1
+	}
+
+	var s string
+	if precision > 0 {
+		s = strconv.FormatFloat(math.Abs(f), 'e', precision-1, 64)
+	} else {
+		s = strconv.FormatFloat(math.Abs(f), 'e', -1, 64)
+	}
+
+	// s 形如 "1.2345e+06"
+	mantissa, exp, _ := strings.Cut(s, "e")
+	decpt, _ = strconv.Atoi(exp)
+	digits = strings.Replace(mantissa, ".", "", 1)
+	digits = strings.TrimRight(digits, "0")
+	if digits == "" {
+		digits = "0"
+	}
+	return digits, decpt + 1
+}
+
+// FormatFloat 按 PHP 规则将浮点数转为字符串，对应 PHP 源码中的 zend_gcvt()
+// - precision 为有效位数，为 0 时按 1 处理，为 -1 时使用最短的可往返表示(此时科学计数法阈值按 17 位计算)
+// - 小数点位置超出有效位数或小于 -3 时使用科学计数法，e.g. "1.0E+25"、"1.0E-5"
+// - expChar 为科学计数法中的指数字符，一般为 'E'，json_encode() 中为 'e'
+// - 无穷大及 NaN 输出 "INF"、"-INF"、"NAN"
+func FormatFloat(f float64, precision int, expChar byte) string {
+	if precision == 0 {
+		precision = 1
+	}
+	ndigit := precision
+	if precision < 0 {
+		ndigit = 17
+	}
+
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		var s string
+		switch {
+		case math.IsNaN(f):
+			s = "NAN"
+		case f < 0:
+			s = "-INF"
+		default:
+			s = "INF"
+		}
+		// 与 PHP 一致: 输出长度不超过 ndigit
+		if len(s) > ndigit {
+			s = s[:ndigit]
+		}
+		return s
+	}
+
+	digits, decpt := decimalDigits(f, precision)
+
+	var buf strings.Builder
+	if math.Signbit(f) {
+		buf.WriteByte('-')
+	}
+	switch {
+	case decpt < -3 || decpt > ndigit:
+		// 科学计数法，e.g. 1.0E+25
+		exp := decpt - 1
+		buf.WriteByte(digits[0])
+		buf.WriteByte('.')
+		if len(digits) == 1 {
+			buf.WriteByte('0')
+		} else {
+			buf.WriteString(digits[1:])
+		}
+		buf.WriteByte(expChar)
+		if exp < 0 {
+			buf.WriteByte('-')
+			exp = -exp
+		} else {
+			buf.WriteByte('+')
+		}
+		buf.WriteString(strconv.Itoa(exp))
+	case decpt <= 0:
+		// 0.000ddd
+		buf.WriteString("0.")
+		buf.WriteString(strings.Repeat("0", -decpt))
+		buf.WriteString(digits)
+	case decpt >= len(digits):
+		// 整数，不足位数补 0
+		buf.WriteString(digits)
+		buf.WriteString(strings.Repeat("0", decpt-len(digits)))
+	default:
+		buf.WriteString(digits[:decpt])
+		buf.WriteByte('.')
+		buf.WriteString(digits[decpt:])
+	}
+	return buf.String()
+}
+
+// ToString 浮点数转字符串，对应 PHP 中 echo、(string) 强制转换及字符串拼接的行为(precision=14)
+// e.g. 0.1+0.2 => "0.3"、1e15 => "1.0E+15"、-0.0 => "-0"
+func ToString(f float64) string {
+	return FormatFloat(f, DefaultPrecision, 'E')
+}
+
+// VarExport 浮点数转字符串，对应 PHP 函数 var_export() 的输出(serialize_precision=-1)
+// 有限值的结果中不含小数点及指数时补充 ".0"，e.g. 1.0 => "1.0"、0.1+0.2 => "0.30000000000000004"
+func VarExport(f float64) string {
+	s := FormatFloat(f, SerializePrecision, 'E')
+	if !math.IsInf(f, 0) && !math.IsNaN(f) && !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// JSONEncode 浮点数转字符串，对应 PHP 函数 json_encode() 的输出(serialize_precision=-1)
+// 与 VarExport() 的区别是指数字符为小写 'e'，且默认不补充 ".0" (对应 JSON_PRESERVE_ZERO_FRACTION 选项)
+func JSONEncode(f float64, preserveZeroFraction bool) string {
+	s := FormatFloat(f, SerializePrecision, 'e')
+	if preserveZeroFraction && !math.IsInf(f, 0) && !math.IsNaN(f) && !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,133 @@
+package numfmt
+
+import (
+	"math"
+	"testing"
+)
+
+// 以下期望值均为 PHP 8.4 的输出
+
+// pointThree 运行时计算的 0.1+0.2，避免常量表达式被精确求值
+var pointThree = func(a, b float64) float64 { return a + b }(0.1, 0.2)
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		{0, "0"},
+		{math.Copysign(0, -1), "-0"},
+		{1, "1"},
+		{-1, "-1"},
+		{100, "100"},
+		{2.5, "2.5"},
+		{-2.5, "-2.5"},
+		{0.1, "0.1"},
+		{pointThree, "0.3"},
+		{1.0 / 3, "0.33333333333333"},
+		{2.0 / 3, "0.66666666666667"},
+		{1e13, "10000000000000"},
+		{99999999999999, "99999999999999"},
+		{1e14, "1.0E+14"},
+		{1e15, "1.0E+15"},
+		{123456789012345.678, "1.2345678901235E+14"},
+		{1e22, "1.0E+22"},
+		{1e25, "1.0E+25"},
+		{-1.5e300, "-1.5E+300"},
+		{math.MaxFloat64, "1.7976931348623E+308"},
+		{0.0001, "0.0001"},
+		{0.00012345, "0.00012345"},
+		{0.00001, "1.0E-5"},
+		{1.5e-7, "1.5E-7"},
+		{5e-324, "4.9406564584125E-324"},
+		{float64(math.MaxInt64), "9.2233720368548E+18"},
+		{math.Inf(1), "INF"},
+		{math.Inf(-1), "-INF"},
+		{math.NaN(), "NAN"},
+	}
+	for _, tt := range tests {
+		if got := ToString(tt.f); got != tt.want {
+			t.Errorf("ToString(%v) = %v, want %v", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestVarExport(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		{0, "0.0"},
+		{math.Copysign(0, -1), "-0.0"},
+		{1, "1.0"},
+		{-1, "-1.0"},
+		{0.1, "0.1"},
+		{pointThree, "0.30000000000000004"},
+		{1.0 / 3, "0.3333333333333333"},
+		{1e15, "1000000000000000.0"},
+		{1e17, "1.0E+17"},
+		{1e25, "1.0E+25"},
+		{float64(math.MaxInt64), "9.223372036854776E+18"},
+		{123456789.12345678, "123456789.12345678"},
+		{0.0001, "0.0001"},
+		{0.00001, "1.0E-5"},
+		{5e-324, "5.0E-324"},
+		{math.MaxFloat64, "1.7976931348623157E+308"},
+		{math.Inf(1), "INF"},
+		{math.Inf(-1), "-INF"},
+		{math.NaN(), "NAN"},
+	}
+	for _, tt := range tests {
+		if got := VarExport(tt.f); got != tt.want {
+			t.Errorf("VarExport(%v) = %v, want %v", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestJSONEncode(t *testing.T) {
+	tests := []struct {
+		f        float64
+		preserve bool
+		want     string
+	}{
+		{1, false, "1"},
+		{1, true, "1.0"},
+		{-0.5, false, "-0.5"},
+		{pointThree, false, "0.30000000000000004"},
+		{1e25, false, "1.0e+25"},
+		{1e25, true, "1.0e+25"},
+		{1.5e-10, false, "1.5e-10"},
+	}
+	for _, tt := range tests {
+		if got := JSONEncode(tt.f, tt.preserve); got != tt.want {
+			t.Errorf("JSONEncode(%v, %v) = %v, want %v", tt.f, tt.preserve, got, tt.want)
+		}
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		f         float64
+		precision int
+		want      string
+	}{
+		{1234.5678, 0, "1.0E+3"},
+		{1234.5678, 1, "1.0E+3"},
+		{1234.5678, 2, "1.2E+3"},
+		{1234.5678, 4, "1235"},
+		{1234.5678, 6, "1234.57"},
+		{1234.5678, 17, "1234.5678"},
+		{0.1, 17, "0.10000000000000001"},
+		{0.1, 20, "0.10000000000000000555"},
+		{2.5, 1, "2"},
+		{3.5, 1, "4"},
+		{math.Inf(1), 1, "I"},
+		{math.Inf(-1), 2, "-I"},
+		{math.NaN(), 3, "NAN"},
+	}
+	for _, tt := range tests {
+		if got := FormatFloat(tt.f, tt.precision, 'E'); got != tt.want {
+			t.Errorf("FormatFloat(%v, %d) = %v, want %v", tt.f, tt.precision, got, tt.want)
+		}
+	}
+}
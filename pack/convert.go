@@ -0,0 +1,125 @@
+package pack
+
+import (
+	"github.com/heyuuu/gophp-utils/numeric"
+	"github.com/heyuuu/gophp-utils/numfmt"
+	"math"
+	"strconv"
+)
+
+// toInt 按 PHP zval_get_long() 规则将参数转为整数
+// 字符串取其前导数字部分，非数字字符串为 0；其他不支持的类型为 0
+func toInt(v any) int64 {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float32:
+		return floatToInt(float64(v))
+	case float64:
+		return floatToInt(v)
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case string:
+		return numberToInt(numeric.Classify(v).Number)
+	case []byte:
+		return numberToInt(numeric.Classify(string(v)).Number)
+	default:
+		return 0
+	}
+}
+
+// toFloat 按 PHP zval_get_double() 规则将参数转为浮点数
+func toFloat(v any) float64 {
+	switch v := v.(type) {
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	case string:
+		return numberToFloat(numeric.Classify(v).Number)
+	case []byte:
+		return numberToFloat(numeric.Classify(string(v)).Number)
+	default:
+		return float64(toInt(v))
+	}
+}
+
+// toString 按 PHP zval_get_string() 规则将参数转为字符串
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float32:
+		return numfmt.ToString(float64(v))
+	case float64:
+		return numfmt.ToString(v)
+	case bool:
+		if v {
+			return "1"
+		}
+		return ""
+	case nil:
+		return ""
+	default:
+		return strconv.FormatInt(toInt(v), 10)
+	}
+}
+
+func numberToInt(n numeric.Number) int64 {
+	if n.IsFloat {
+		return floatToInt(n.Float)
+	}
+	return n.Int
+}
+
+func numberToFloat(n numeric.Number) float64 {
+	if n.IsFloat {
+		return n.Float
+	}
+	return float64(n.Int)
+}
+
+// floatToInt 按 PHP zend_dval_to_lval() 规则将浮点数转为整数
+// 无穷大及 NaN 为 0，超出 int64 范围时按 2^64 取模
+func floatToInt(f float64) int64 {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0
+	}
+	if f >= -(1<<63) && f < 1<<63 {
+		return int64(f)
+	}
+	const twoPow64 = 1 << 64
+	m := math.Mod(f, twoPow64)
+	if m < 0 {
+		m += twoPow64
+	}
+	if m >= twoPow64 {
+		// 加法舍入后可能恰好等于 2^64
+		return 0
+	}
+	// m 在 [0, 2^64) 范围内，按无符号数转换后解释为有符号数
+	return int64(uint64(m))
+}
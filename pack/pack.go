@@ -0,0 +1,252 @@
+package pack
+
+// PHP 二进制打包函数 pack()、unpack() 的实现
+// 支持的格式代码:
+//   a  NUL 填充的字符串          A  空格填充的字符串          Z  NUL 填充且以 NUL 结尾的字符串
+//   h  十六进制字符串，低半字节在前  H  十六进制字符串，高半字节在前
+//   c  有符号 char               C  无符号 char
+//   s  有符号 16 位，机器字节序    S  无符号 16 位，机器字节序
+//   n  无符号 16 位，大端序        v  无符号 16 位，小端序
+//   i  有符号 int(32 位)，机器字节序 I  无符号 int(32 位)，机器字节序
+//   l  有符号 32 位，机器字节序    L  无符号 32 位，机器字节序
+//   N  无符号 32 位，大端序        V  无符号 32 位，小端序
+//   q  有符号 64 位，机器字节序    Q  无符号 64 位，机器字节序
+//   J  无符号 64 位，大端序        P  无符号 64 位，小端序
+//   f  float，机器字节序          g  float，小端序             G  float，大端序
+//   d  double，机器字节序         e  double，小端序            E  double，大端序
+//   x  NUL 字节                  X  回退一个字节               @  填充 NUL 至绝对位置
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/heyuuu/gophp-utils/ascii"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrUnknownFormat      = errors.New("unknown format code")
+	ErrTooFewArguments    = errors.New("too few arguments")
+	ErrNotEnoughArguments = errors.New("not enough arguments")
+	ErrIntegerOverflow    = errors.New("integer overflow in format string")
+	ErrNotEnoughInput     = errors.New("not enough input")
+	ErrInvalidOffset      = errors.New("offset must be contained in data")
+)
+
+// FormatError 格式错误，对应 PHP 中 pack()、unpack() 抛出的 ValueError 或返回 false 的情况
+type FormatError struct {
+	Func string // "pack" 或 "unpack"
+	Code byte   // 出错的格式代码
+	Err  error
+}
+
+func (e *FormatError) Error() string {
+	return e.Func + ": type " + string([]byte{e.Code}) + ": " + e.Err.Error()
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// parseCount 解析 format[i:] 处的重复次数，返回次数(-1 表示 '*')及结束位置；没有重复次数时返回 def
+func parseCount(format string, i int, def int) (count int, end int, overflow bool) {
+	if i < len(format) && format[i] == '*' {
+		return -1, i + 1, false
+	}
+	if i >= len(format) || !ascii.IsDigit(format[i]) {
+		return def, i, false
+	}
+	end = i
+	for end < len(format) && ascii.IsDigit(format[end]) {
+		end++
+	}
+	n, err := strconv.ParseInt(format[i:end], 10, 32)
+	if err != nil {
+		return 0, end, true
+	}
+	return int(n), end, false
+}
+
+// intSize 返回整数格式代码对应的字节数，非整数格式代码返回 0
+func intSize(code byte) int {
+	switch code {
+	case 'c', 'C':
+		return 1
+	case 's', 'S', 'n', 'v':
+		return 2
+	case 'i', 'I', 'l', 'L', 'N', 'V':
+		return 4
+	case 'q', 'Q', 'J', 'P':
+		return 8
+	default:
+		return 0
+	}
+}
+
+// byteOrder 返回格式代码对应的字节序
+func byteOrder(code byte) binary.ByteOrder {
+	switch code {
+	case 'n', 'N', 'J', 'G', 'E':
+		return binary.BigEndian
+	case 'v', 'V', 'P', 'g', 'e':
+		return binary.LittleEndian
+	default:
+		return binary.NativeEndian
+	}
+}
+
+// Pack 将参数按格式打包为二进制字符串，对应 PHP 函数 pack()
+// - 格式代码之后可跟重复次数或 '*'；字符串类格式代码的重复次数为长度，'*' 表示整个字符串
+// - 参数按 PHP 规则转换类型: 整数类格式代码接受整数、浮点数、数字字符串及 bool，字符串类格式代码接受字符串、[]byte 及数值
+// - 非法的十六进制字符按 0 处理，多余的参数被忽略，'X' 回退超出开头时停在开头(PHP 中这些情况产生 Warning)
+func Pack(format string, args ...any) ([]byte, error) {
+	var out []byte
+	pos := 0
+	argIndex := 0
+
+	// grow 保证 out 的长度不小于 n，新增部分以 NUL 填充
+	grow := func(n int) {
+		if n > len(out) {
+			out = append(out, make([]byte, n-len(out))...)
+		}
+	}
+
+	for i := 0; i < len(format); {
+		code := format[i]
+		count, next, overflow := parseCount(format, i+1, 1)
+		if overflow {
+			return nil, &FormatError{"pack", code, ErrIntegerOverflow}
+		}
+		i = next
+
+		switch code {
+		case 'a', 'A', 'Z', 'h', 'H':
+			if argIndex >= len(args) {
+				return nil, &FormatError{"pack", code, ErrNotEnoughArguments}
+			}
+			s := toString(args[argIndex])
+			argIndex++
+
+			if code == 'h' || code == 'H' {
+				if count < 0 || count > len(s) {
+					count = len(s)
+				}
+				n := (count + 1) / 2
+				grow(pos + n)
+				packHex(out[pos:pos+n], s[:count], code == 'H')
+				pos += n
+				break
+			}
+
+			if count < 0 {
+				count = len(s)
+				if code == 'Z' {
+					count++
+				}
+			}
+			grow(pos + count)
+			field := out[pos : pos+count]
+			pad := byte(0)
+			if code == 'A' {
+				pad = ' '
+			}
+			for k := range field {
+				field[k] = pad
+			}
+			limit := count
+			if code == 'Z' && limit > 0 {
+				limit-- // 保留末尾的 NUL
+			}
+			copy(field[:limit], s)
+			pos += count
+
+		case 'c', 'C', 's', 'S', 'n', 'v', 'i', 'I', 'l', 'L', 'N', 'V', 'q', 'Q', 'J', 'P', 'f', 'g', 'G', 'd', 'e', 'E':
+			if count < 0 {
+				count = len(args) - argIndex
+			}
+			if argIndex+count > len(args) {
+				return nil, &FormatError{"pack", code, ErrTooFewArguments}
+			}
+			for ; count > 0; count-- {
+				arg := args[argIndex]
+				argIndex++
+				switch code {
+				case 'f', 'g', 'G':
+					grow(pos + 4)
+					byteOrder(code).PutUint32(out[pos:], math.Float32bits(float32(toFloat(arg))))
+					pos += 4
+				case 'd', 'e', 'E':
+					grow(pos + 8)
+					byteOrder(code).PutUint64(out[pos:], math.Float64bits(toFloat(arg)))
+					pos += 8
+				default:
+					size := intSize(code)
+					grow(pos + size)
+					putInt(out[pos:pos+size], byteOrder(code), uint64(toInt(arg)))
+					pos += size
+				}
+			}
+
+		case 'x':
+			if count < 0 {
+				count = 1 // PHP: '*' ignored
+			}
+			// 可能由 'X' 回退，需覆盖已有内容
+			grow(pos + count)
+			clear(out[pos : pos+count])
+			pos += count
+
+		case 'X':
+			if count < 0 {
+				count = 1 // PHP: '*' ignored
+			}
+			pos = max(pos-count, 0)
+
+		case '@':
+			if count < 0 {
+				count = 1 // PHP: '*' ignored
+			}
+			if count > pos {
+				grow(count)
+				clear(out[pos:count])
+			}
+			pos = count
+
+		default:
+			return nil, &FormatError{"pack", code, ErrUnknownFormat}
+		}
+	}
+
+	grow(pos)
+	return out[:pos], nil
+}
+
+// putInt 按字节序写入 len(b) 字节的整数
+func putInt(b []byte, order binary.ByteOrder, v uint64) {
+	switch len(b) {
+	case 1:
+		b[0] = byte(v)
+	case 2:
+		order.PutUint16(b, uint16(v))
+	case 4:
+		order.PutUint32(b, uint32(v))
+	case 8:
+		order.PutUint64(b, v)
+	}
+}
+
+// packHex 将十六进制字符串写入 out，highFirst 为 true 时高半字节在前('H')
+func packHex(out []byte, s string, highFirst bool) {
+	for k := 0; k < len(s); k++ {
+		n, _ := ascii.ParseXDigit(s[k]) // 与 PHP 一致，非法字符按 0 处理
+		// 偶数位置为字节的第一个半字节
+		if (k%2 == 0) == highFirst {
+			n <<= 4
+		}
+		if k%2 == 0 {
+			out[k/2] = n
+		} else {
+			out[k/2] |= n
+		}
+	}
+}
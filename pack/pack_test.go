@@ -0,0 +1,221 @@
+package pack
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// native 按机器字节序编码，用于机器字节序格式代码的期望值
+func native(size int, v uint64) string {
+	b := make([]byte, size)
+	switch size {
+	case 2:
+		binary.NativeEndian.PutUint16(b, uint16(v))
+	case 4:
+		binary.NativeEndian.PutUint32(b, uint32(v))
+	case 8:
+		binary.NativeEndian.PutUint64(b, v)
+	}
+	return string(b)
+}
+
+func TestPack(t *testing.T) {
+	tests := []struct {
+		format string
+		args   []any
+		want   string
+	}{
+		{"", nil, ""},
+		{"a", []any{"abc"}, "a"},
+		{"a5", []any{"abc"}, "abc\x00\x00"},
+		{"a*", []any{"abc"}, "abc"},
+		{"A5", []any{"abc"}, "abc  "},
+		{"A2", []any{"abc"}, "ab"},
+		{"Z5", []any{"abc"}, "abc\x00\x00"},
+		{"Z3", []any{"abc"}, "ab\x00"},
+		{"Z*", []any{"abc"}, "abc\x00"},
+		{"Z0", []any{"abc"}, ""},
+		{"H*", []any{"48656c6c6f"}, "Hello"},
+		{"H3", []any{"abc"}, "\xab\xc0"},
+		{"H2", []any{"abcd"}, "\xab"},
+		{"H4", []any{"ab"}, "\xab"},
+		{"h*", []any{"8456"}, "\x48\x65"},
+		{"h3", []any{"abc"}, "\xba\x0c"},
+		{"H2", []any{"zz"}, "\x00"},
+		{"c", []any{-1}, "\xff"},
+		{"C3", []any{65, 66, 67}, "ABC"},
+		{"C*", []any{1, 2, 3, 4}, "\x01\x02\x03\x04"},
+		{"C", []any{256 + 65}, "A"},
+		{"n", []any{0x1234}, "\x12\x34"},
+		{"v", []any{0x1234}, "\x34\x12"},
+		{"N", []any{0x12345678}, "\x12\x34\x56\x78"},
+		{"V", []any{0x12345678}, "\x78\x56\x34\x12"},
+		{"N", []any{-1}, "\xff\xff\xff\xff"},
+		{"J", []any{0x0102030405060708}, "\x01\x02\x03\x04\x05\x06\x07\x08"},
+		{"P", []any{0x0102030405060708}, "\x08\x07\x06\x05\x04\x03\x02\x01"},
+		{"J", []any{-2}, "\xff\xff\xff\xff\xff\xff\xff\xfe"},
+		{"s", []any{-2}, native(2, 0xfffe)},
+		{"S", []any{0x1234}, native(2, 0x1234)},
+		{"i", []any{-2}, native(4, 0xfffffffe)},
+		{"l", []any{0x12345678}, native(4, 0x12345678)},
+		{"L", []any{0x12345678}, native(4, 0x12345678)},
+		{"q", []any{-2}, native(8, 0xfffffffffffffffe)},
+		{"Q", []any{0x0102030405060708}, native(8, 0x0102030405060708)},
+		{"G", []any{1.5}, "\x3f\xc0\x00\x00"},
+		{"g", []any{1.5}, "\x00\x00\xc0\x3f"},
+		{"E", []any{1.5}, "\x3f\xf8\x00\x00\x00\x00\x00\x00"},
+		{"e", []any{1.5}, "\x00\x00\x00\x00\x00\x00\xf8\x3f"},
+		{"f", []any{1.5}, native(4, 0x3fc00000)},
+		{"d", []any{1.5}, native(8, 0x3ff8000000000000)},
+		{"E", []any{"1.5"}, "\x3f\xf8\x00\x00\x00\x00\x00\x00"},
+		{"x", nil, "\x00"},
+		{"x3", nil, "\x00\x00\x00"},
+		{"a3X", []any{"abc"}, "ab"},
+		{"a3X2x", []any{"abc"}, "a\x00"},
+		{"X", nil, ""},
+		{"a*X5", []any{"abc"}, ""},
+		{"a@5", []any{"abc"}, "a\x00\x00\x00\x00"},
+		{"a3@1", []any{"abc"}, "a"},
+		{"a3@1a", []any{"abc", "X"}, "aX"},
+		{"@2C", []any{1}, "\x00\x00\x01"},
+		// 参数类型转换
+		{"C", []any{"65"}, "A"},
+		{"C", []any{" 66abc"}, "B"},
+		{"C", []any{"abc"}, "\x00"},
+		{"C", []any{67.9}, "C"},
+		{"C", []any{true}, "\x01"},
+		{"N", []any{"0x10"}, "\x00\x00\x00\x00"},
+		{"J", []any{1e19}, "\x8a\xc7\x23\x04\x89\xe8\x00\x00"},
+		{"J", []any{math.Inf(1)}, "\x00\x00\x00\x00\x00\x00\x00\x00"},
+		{"a*", []any{12}, "12"},
+		{"a*", []any{1.5}, "1.5"},
+		{"a*", []any{0.1 + 0.7}, "0.8"},
+		{"a*", []any{[]byte("xy")}, "xy"},
+		// 多余参数被忽略
+		{"C", []any{1, 2}, "\x01"},
+		{"nvc*", []any{0x1234, 0x5678, 65, 66}, "\x12\x34\x78\x56AB"},
+	}
+	for _, tt := range tests {
+		got, err := Pack(tt.format, tt.args...)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("Pack(%q, %v) = %q, %v, want %q", tt.format, tt.args, got, err, tt.want)
+		}
+	}
+}
+
+func TestPackError(t *testing.T) {
+	tests := []struct {
+		format   string
+		args     []any
+		wantCode byte
+		wantErr  error
+	}{
+		{"y", nil, 'y', ErrUnknownFormat},
+		{"a", nil, 'a', ErrNotEnoughArguments},
+		{"C2", []any{1}, 'C', ErrTooFewArguments},
+		{"NN", []any{1}, 'N', ErrTooFewArguments},
+		{"C99999999999", []any{1}, 'C', ErrIntegerOverflow},
+	}
+	for _, tt := range tests {
+		_, err := Pack(tt.format, tt.args...)
+		var fe *FormatError
+		if !errors.Is(err, tt.wantErr) || !errors.As(err, &fe) || fe.Code != tt.wantCode {
+			t.Errorf("Pack(%q) error = %v, want %v", tt.format, err, tt.wantErr)
+		}
+	}
+	if _, err := Pack("C2", 1); err == nil || err.Error() != "pack: type C: too few arguments" {
+		t.Errorf("Pack() error message = %v", err)
+	}
+	if _, err := Pack("\xff"); err == nil || err.Error() != "pack: type \xff: unknown format code" {
+		t.Errorf("Pack() error message = %q", err)
+	}
+}
+
+// TestRoundTrip 随机数据打包后解包应得到原值
+func TestRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	intCodes := []struct {
+		code byte
+		bits int
+		sign bool
+	}{
+		{'c', 8, true}, {'C', 8, false},
+		{'s', 16, true}, {'S', 16, false}, {'n', 16, false}, {'v', 16, false},
+		{'i', 32, true}, {'I', 32, false}, {'l', 32, true}, {'L', 32, false}, {'N', 32, false}, {'V', 32, false},
+		{'q', 64, true}, {'Q', 64, true}, {'J', 64, true}, {'P', 64, true},
+	}
+	for _, c := range intCodes {
+		for range 200 {
+			v := int64(rnd.Uint64())
+			// 期望值: 截断至对应位数后按有无符号扩展
+			want := v
+			if c.bits < 64 {
+				shift := 64 - c.bits
+				if c.sign {
+					want = v << shift >> shift
+				} else {
+					want = int64(uint64(v) << shift >> shift)
+				}
+			}
+
+			packed, err := Pack(string(c.code), v)
+			if err != nil || len(packed) != c.bits/8 {
+				t.Fatalf("Pack(%q, %d) = %q, %v", c.code, v, packed, err)
+			}
+			result, err := Unpack(string(c.code)+"v", packed, 0)
+			if err != nil {
+				t.Fatalf("Unpack(%q) error: %v", c.code, err)
+			}
+			if got, _ := result.Get("v"); got != want {
+				t.Errorf("round trip %c: %d => %v, want %d", c.code, v, got, want)
+			}
+		}
+	}
+
+	for _, code := range []byte("dEe") {
+		for range 200 {
+			v := math.Float64frombits(rnd.Uint64())
+			if math.IsNaN(v) {
+				continue
+			}
+			packed, _ := Pack(string(code), v)
+			result, _ := Unpack(string(code), packed, 0)
+			if got, _ := result.Get("1"); got != v {
+				t.Errorf("round trip %c: %v => %v", code, v, got)
+			}
+		}
+	}
+	for _, code := range []byte("fgG") {
+		for range 200 {
+			v := float64(math.Float32frombits(rnd.Uint32()))
+			if math.IsNaN(v) {
+				continue
+			}
+			packed, _ := Pack(string(code), v)
+			result, _ := Unpack(string(code), packed, 0)
+			if got, _ := result.Get("1"); got != v {
+				t.Errorf("round trip %c: %v => %v", code, v, got)
+			}
+		}
+	}
+
+	for range 200 {
+		raw := make([]byte, rnd.Intn(16))
+		rnd.Read(raw)
+		packed, _ := Pack("a*", string(raw))
+		result, _ := Unpack("a*s", packed, 0)
+		if got, _ := result.Get("s"); got != string(raw) {
+			t.Errorf("round trip a*: %q => %q", raw, got)
+		}
+
+		hex, _ := Unpack("H*", raw, 0)
+		h, _ := hex.Get("1")
+		back, _ := Pack("H*", h)
+		if string(back) != string(raw) {
+			t.Errorf("round trip H*: %q => %v => %q", raw, h, back)
+		}
+	}
+}
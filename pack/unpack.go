@@ -0,0 +1,226 @@
+package pack
+
+import (
+	"math"
+	"strconv"
+)
+
+// Field unpack 结果中的一个元素
+type Field struct {
+	Key   string
+	Value any // int64、float64 或 string
+}
+
+// Result unpack 的结果，按 PHP 数组的插入顺序排列
+type Result []Field
+
+// Get 返回指定键的值
+func (r Result) Get(key string) (any, bool) {
+	for _, f := range r {
+		if f.Key == key {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Map 将结果转为 map
+func (r Result) Map() map[string]any {
+	m := make(map[string]any, len(r))
+	for _, f := range r {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+// set 设置指定键的值；键已存在时原位覆盖，与 PHP 数组一致
+func (r *Result) set(key string, value any) {
+	for i := range *r {
+		if (*r)[i].Key == key {
+			(*r)[i].Value = value
+			return
+		}
+	}
+	*r = append(*r, Field{key, value})
+}
+
+// Unpack 按格式从二进制字符串中解包数据，对应 PHP 函数 unpack()
+// - 格式为以 '/' 分隔的多个元素，每个元素为 格式代码 + 可选的重复次数(或 '*') + 可选的名称，e.g. "Clen/a*data"
+// - 元素的键名规则与 PHP 一致: 重复次数为 1 且有名称时为名称，否则为名称加上从 1 开始的序号，e.g. "C2x" => "x1"、"x2"
+// - 'a' 保留填充字符；'A' 去除末尾的空白及 NUL；'Z' 截断至首个 NUL；'h'、'H' 返回小写十六进制字符串
+// - 整数返回 int64 ('Q'、'J'、'P' 的最高位为 1 时为负数)，浮点数返回 float64
+// - 数据不足时返回 ErrNotEnoughInput；'X'、'@' 超出数据范围时忽略(PHP 中产生 Warning)
+// offset 为开始解包的位置，需在 [0, len(data)] 范围内
+func Unpack(format string, data []byte, offset int) (Result, error) {
+	if offset < 0 || offset > len(data) {
+		return nil, &FormatError{"unpack", 0, ErrInvalidOffset}
+	}
+	input := data[offset:]
+	pos := 0
+	result := Result{}
+
+	for i := 0; i < len(format); {
+		code := format[i]
+		count, next, overflow := parseCount(format, i+1, 1)
+		if overflow {
+			return nil, &FormatError{"unpack", code, ErrIntegerOverflow}
+		}
+		i = next
+
+		// 名称持续到下一个 '/'
+		nameStart := i
+		for i < len(format) && format[i] != '/' {
+			i++
+		}
+		name := format[nameStart:i]
+		if len(name) > 200 {
+			name = name[:200]
+		}
+		if i < len(format) {
+			i++ // 跳过 '/'
+		}
+
+		// size 为每次重复消耗的字节数，字符串类格式代码的重复次数即为长度
+		size := 0
+		hexDigits := count // 'h'、'H' 的半字节数
+		switch code {
+		case 'X', '@':
+			if count < 0 {
+				count = 1 // PHP: '*' ignored
+			}
+		case 'a', 'A', 'Z':
+			size, count = count, 1
+		case 'h', 'H':
+			if count > 0 {
+				size = (count + 1) / 2
+			} else {
+				size = count
+			}
+			count = 1
+		case 'x':
+			size = 1
+		case 'f', 'g', 'G':
+			size = 4
+		case 'd', 'e', 'E':
+			size = 8
+		default:
+			size = intSize(code)
+			if size == 0 {
+				return nil, &FormatError{"unpack", code, ErrUnknownFormat}
+			}
+		}
+
+		for rep := 0; rep != count; rep++ {
+			if code == 'X' {
+				// 每次回退一个字节
+				if pos > 0 {
+					pos--
+				}
+				continue
+			}
+			if code == '@' {
+				// 跳至相对 offset 的绝对位置，只执行一次
+				if count <= len(input) {
+					pos = count
+				}
+				break
+			}
+
+			if pos+max(size, 0) > len(input) {
+				if count < 0 {
+					// '*' 重复至数据结尾
+					break
+				}
+				return nil, &FormatError{"unpack", code, ErrNotEnoughInput}
+			}
+
+			key := name
+			if count != 1 || name == "" {
+				key = name + strconv.Itoa(rep+1)
+			}
+
+			remain := input[pos:]
+			n := len(remain)
+			if size >= 0 && n > size {
+				n = size
+			}
+			switch code {
+			case 'a':
+				result.set(key, string(remain[:n]))
+				size = n
+			case 'A':
+				end := n
+				for end > 0 && isPadding(remain[end-1]) {
+					end--
+				}
+				result.set(key, string(remain[:end]))
+				size = n
+			case 'Z':
+				end := 0
+				for end < n && remain[end] != 0 {
+					end++
+				}
+				result.set(key, string(remain[:end]))
+				size = n
+			case 'h', 'H':
+				digits := n * 2
+				if digits > 0 && hexDigits > 0 {
+					// 奇数个半字节时不输出最后一个
+					digits -= hexDigits % 2
+				}
+				result.set(key, unpackHex(remain[:n], digits, code == 'H'))
+				size = n
+			case 'x':
+				// 跳过 1 字节
+			case 'f', 'g', 'G':
+				result.set(key, float64(math.Float32frombits(byteOrder(code).Uint32(remain))))
+			case 'd', 'e', 'E':
+				result.set(key, math.Float64frombits(byteOrder(code).Uint64(remain)))
+			default:
+				result.set(key, readInt(remain[:size], code))
+			}
+			pos += size
+		}
+	}
+	return result, nil
+}
+
+// isPadding 判断是否为 'A' 格式需去除的末尾字符
+func isPadding(c byte) bool {
+	return c == 0 || c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// readInt 按格式代码读取整数
+func readInt(b []byte, code byte) int64 {
+	order := byteOrder(code)
+	switch code {
+	case 'c':
+		return int64(int8(b[0]))
+	case 'C':
+		return int64(b[0])
+	case 's':
+		return int64(int16(order.Uint16(b)))
+	case 'S', 'n', 'v':
+		return int64(order.Uint16(b))
+	case 'i', 'l':
+		return int64(int32(order.Uint32(b)))
+	case 'I', 'L', 'N', 'V':
+		return int64(order.Uint32(b))
+	default: // 'q', 'Q', 'J', 'P'
+		return int64(order.Uint64(b))
+	}
+}
+
+// unpackHex 将 b 转为 n 个半字节组成的小写十六进制字符串，highFirst 为 true 时高半字节在前('H')
+func unpackHex(b []byte, n int, highFirst bool) string {
+	const digits = "0123456789abcdef"
+	buf := make([]byte, n)
+	for k := range buf {
+		c := b[k/2]
+		if (k%2 == 0) == highFirst {
+			c >>= 4
+		}
+		buf[k] = digits[c&0xf]
+	}
+	return string(buf)
+}
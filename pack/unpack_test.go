@@ -0,0 +1,111 @@
+package pack
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUnpack(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+		offset int
+		want   Result
+	}{
+		{"", "abc", 0, Result{}},
+		{"C", "A", 0, Result{{"1", int64(65)}}},
+		{"Cx", "A", 0, Result{{"x", int64(65)}}},
+		{"C*", "ABC", 0, Result{{"1", int64(65)}, {"2", int64(66)}, {"3", int64(67)}}},
+		{"C*x", "AB", 0, Result{{"x1", int64(65)}, {"x2", int64(66)}}},
+		{"C2x/Cy", "ABC", 0, Result{{"x1", int64(65)}, {"x2", int64(66)}, {"y", int64(67)}}},
+		{"c", "\xff", 0, Result{{"1", int64(-1)}}},
+		{"n", "\x12\x34", 0, Result{{"1", int64(0x1234)}}},
+		{"v", "\x12\x34", 0, Result{{"1", int64(0x3412)}}},
+		{"N", "\xff\xff\xff\xff", 0, Result{{"1", int64(0xffffffff)}}},
+		{"V", "\x78\x56\x34\x12", 0, Result{{"1", int64(0x12345678)}}},
+		{"J", "\xff\xff\xff\xff\xff\xff\xff\xfe", 0, Result{{"1", int64(-2)}}},
+		{"P", "\x08\x07\x06\x05\x04\x03\x02\x01", 0, Result{{"1", int64(0x0102030405060708)}}},
+		{"s", native(2, 0xfffe), 0, Result{{"1", int64(-2)}}},
+		{"S", native(2, 0xfffe), 0, Result{{"1", int64(0xfffe)}}},
+		{"l", native(4, 0xfffffffe), 0, Result{{"1", int64(-2)}}},
+		{"L", native(4, 0xfffffffe), 0, Result{{"1", int64(0xfffffffe)}}},
+		{"i", native(4, 0xfffffffe), 0, Result{{"1", int64(-2)}}},
+		{"I", native(4, 0xfffffffe), 0, Result{{"1", int64(0xfffffffe)}}},
+		{"G", "\x3f\xc0\x00\x00", 0, Result{{"1", 1.5}}},
+		{"g", "\x00\x00\xc0\x3f", 0, Result{{"1", 1.5}}},
+		{"E", "\x3f\xf8\x00\x00\x00\x00\x00\x00", 0, Result{{"1", 1.5}}},
+		{"e", "\x00\x00\x00\x00\x00\x00\xf8\x3f", 0, Result{{"1", 1.5}}},
+		// 字符串
+		{"a5", "ab\x00 \x00cd", 0, Result{{"1", "ab\x00 \x00"}}},
+		{"A5", "ab\x00 \x00cd", 0, Result{{"1", "ab"}}},
+		{"A*", "ab \t\r\n\x00", 0, Result{{"1", "ab"}}},
+		{"Z5", "ab\x00cd", 0, Result{{"1", "ab"}}},
+		{"Z*", "abcd", 0, Result{{"1", "abcd"}}},
+		{"a*", "", 0, Result{{"1", ""}}},
+		{"a3s", "abcdef", 0, Result{{"s", "abc"}}},
+		{"H*", "Hello", 0, Result{{"1", "48656c6c6f"}}},
+		{"h*", "Hello", 0, Result{{"1", "8456c6c6f6"}}},
+		{"H3", "\xab\xcd", 0, Result{{"1", "abc"}}},
+		{"H2", "\xab\xcd", 0, Result{{"1", "ab"}}},
+		{"h3", "\xab\xcd", 0, Result{{"1", "bad"}}},
+		// 带名称的组合格式
+		{"Clen/a*data", "\x03abc", 0, Result{{"len", int64(3)}, {"data", "abc"}}},
+		{"nid/Cflags/A4name", "\x00\x2a\x01bob ", 0, Result{{"id", int64(42)}, {"flags", int64(1)}, {"name", "bob"}}},
+		{"Ca/Cb/Ca", "\x01\x02\x03", 0, Result{{"a", int64(3)}, {"b", int64(2)}}},
+		{"C2/C", "\x01\x02\x03", 0, Result{{"1", int64(3)}, {"2", int64(2)}}},
+		// 定位
+		{"x/C", "\x01\x02", 0, Result{{"1", int64(2)}}},
+		{"x*/C*", "\x01\x02", 0, Result{}},
+		{"C/X/Cb", "\x07", 0, Result{{"1", int64(7)}, {"b", int64(7)}}},
+		{"X5/C", "\x07", 0, Result{{"1", int64(7)}}},
+		{"@2/C", "\x01\x02\x03", 0, Result{{"1", int64(3)}}},
+		{"C/@0/Cb", "\x01\x02", 0, Result{{"1", int64(1)}, {"b", int64(2)}}},
+		{"@9/C", "\x01\x02", 0, Result{{"1", int64(1)}}},
+		{"C", "\x01\x02", 1, Result{{"1", int64(2)}}},
+		{"@1/C", "\x01\x02\x03", 1, Result{{"1", int64(3)}}},
+		{"C*", "\x01\x02", 2, Result{}},
+	}
+	for _, tt := range tests {
+		got, err := Unpack(tt.format, []byte(tt.data), tt.offset)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unpack(%q, %q, %d) = %v, %v, want %v", tt.format, tt.data, tt.offset, got, err, tt.want)
+		}
+	}
+}
+
+func TestUnpackError(t *testing.T) {
+	tests := []struct {
+		format  string
+		data    string
+		offset  int
+		wantErr error
+	}{
+		{"y", "abc", 0, ErrUnknownFormat},
+		{"N", "abc", 0, ErrNotEnoughInput},
+		{"C4", "abc", 0, ErrNotEnoughInput},
+		{"a4", "abc", 0, ErrNotEnoughInput},
+		{"C99999999999", "abc", 0, ErrIntegerOverflow},
+		{"C", "abc", 4, ErrInvalidOffset},
+		{"C", "abc", -1, ErrInvalidOffset},
+	}
+	for _, tt := range tests {
+		_, err := Unpack(tt.format, []byte(tt.data), tt.offset)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Unpack(%q, %q, %d) error = %v, want %v", tt.format, tt.data, tt.offset, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResult(t *testing.T) {
+	r, _ := Unpack("Ca/Cb", []byte{1, 2}, 0)
+	if v, ok := r.Get("b"); !ok || v != int64(2) {
+		t.Errorf("Get(b) = %v, %v", v, ok)
+	}
+	if _, ok := r.Get("c"); ok {
+		t.Errorf("Get(c) should not exist")
+	}
+	if m := r.Map(); !reflect.DeepEqual(m, map[string]any{"a": int64(1), "b": int64(2)}) {
+		t.Errorf("Map() = %v", m)
+	}
+}
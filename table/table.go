@@ -0,0 +1,296 @@
+package table
+
+// 表格渲染相关函数，用于输出对齐的报表(e.g. 基准测试对比、函数列表、差异摘要)
+// 支持纯文本、Markdown 和 CSV 三种输出格式，纯文本与 Markdown 按显示宽度对齐，CJK 字符可正确对齐
+
+import (
+	"encoding/csv"
+	"github.com/heyuuu/gophp-utils/xstrings"
+	"strings"
+)
+
+// Align 列对齐方式
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Format 输出格式
+type Format int
+
+const (
+	FormatText     Format = iota // 纯文本
+	FormatMarkdown               // Markdown 表格
+	FormatCSV                    // CSV (RFC 4180)
+)
+
+// Table 表格
+// Headers 为空时不输出表头(Markdown 格式除外，Markdown 表格必须有表头，此时输出空表头)
+// 各行单元格数可以不同，不足的列按空单元格处理
+type Table struct {
+	Headers []string
+	Rows    [][]string
+	Aligns  []Align // 各列对齐方式，未指定的列左对齐
+	Border  bool    // 是否输出边框，仅对纯文本格式生效
+}
+
+// New 以指定表头创建表格
+func New(headers ...string) *Table {
+	return &Table{Headers: headers}
+}
+
+// SetAligns 设置各列对齐方式
+func (t *Table) SetAligns(aligns ...Align) *Table {
+	t.Aligns = aligns
+	return t
+}
+
+// SetBorder 设置是否输出边框
+func (t *Table) SetBorder(border bool) *Table {
+	t.Border = border
+	return t
+}
+
+// AddRow 添加一行
+func (t *Table) AddRow(cells ...string) *Table {
+	t.Rows = append(t.Rows, cells)
+	return t
+}
+
+// AddRowsFunc 添加多行，类似 xstrings.JoinFunc()，通过转换函数将任意类型转为一行单元格
+func AddRowsFunc[T any](t *Table, elems []T, transform func(T) []string) *Table {
+	for _, elem := range elems {
+		t.Rows = append(t.Rows, transform(elem))
+	}
+	return t
+}
+
+// String 以纯文本格式输出表格
+func (t *Table) String() string {
+	return t.Render(FormatText)
+}
+
+// Render 以指定格式输出表格
+func (t *Table) Render(format Format) string {
+	switch format {
+	case FormatMarkdown:
+		return t.renderMarkdown()
+	case FormatCSV:
+		return t.renderCSV()
+	default:
+		return t.renderText()
+	}
+}
+
+// numColumns 返回列数，为表头及各行单元格数的最大值
+func (t *Table) numColumns() int {
+	n := len(t.Headers)
+	for _, row := range t.Rows {
+		n = max(n, len(row))
+	}
+	return n
+}
+
+func (t *Table) align(col int) Align {
+	if col < len(t.Aligns) {
+		return t.Aligns[col]
+	}
+	return AlignLeft
+}
+
+// normalizeRow 将行补齐到指定列数，并对单元格内容做转换
+func normalizeRow(row []string, columns int, transform func(string) string) []string {
+	result := make([]string, columns)
+	for i, cell := range row {
+		result[i] = transform(cell)
+	}
+	return result
+}
+
+// columnWidths 计算各列显示宽度
+func columnWidths(rows [][]string, columns int, minWidth int) []int {
+	widths := make([]int, columns)
+	for i := range widths {
+		widths[i] = minWidth
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], xstrings.DisplayWidth(cell))
+		}
+	}
+	return widths
+}
+
+// writePadded 按显示宽度填充空格并写入单元格
+func writePadded(buf *strings.Builder, cell string, width int, align Align) {
+	pad := width - xstrings.DisplayWidth(cell)
+	left := 0
+	switch align {
+	case AlignRight:
+		left = pad
+	case AlignCenter:
+		left = pad / 2
+	}
+	buf.WriteString(strings.Repeat(" ", left))
+	buf.WriteString(cell)
+	buf.WriteString(strings.Repeat(" ", pad-left))
+}
+
+// joinLines 将多行单元格合并为一行
+func joinLines(sep string) func(string) string {
+	return func(cell string) string {
+		if !strings.ContainsAny(cell, "\r\n") {
+			return cell
+		}
+		var buf strings.Builder
+		for line, newline := range xstrings.Lines(cell) {
+			buf.WriteString(line)
+			if newline != "" {
+				buf.WriteString(sep)
+			}
+		}
+		return buf.String()
+	}
+}
+
+func (t *Table) renderText() string {
+	columns := t.numColumns()
+	if columns == 0 {
+		return ""
+	}
+
+	transform := joinLines(" ")
+	var rows [][]string
+	if len(t.Headers) > 0 {
+		rows = append(rows, normalizeRow(t.Headers, columns, transform))
+	}
+	for _, row := range t.Rows {
+		rows = append(rows, normalizeRow(row, columns, transform))
+	}
+	widths := columnWidths(rows, columns, 0)
+
+	var buf strings.Builder
+	if t.Border {
+		// +------+-------+
+		separator := func() {
+			for _, width := range widths {
+				buf.WriteByte('+')
+				buf.WriteString(strings.Repeat("-", width+2))
+			}
+			buf.WriteString("+\n")
+		}
+
+		separator()
+		for i, row := range rows {
+			for col, cell := range row {
+				buf.WriteString("| ")
+				writePadded(&buf, cell, widths[col], t.align(col))
+				buf.WriteByte(' ')
+			}
+			buf.WriteString("|\n")
+			if i == 0 && len(t.Headers) > 0 {
+				separator()
+			}
+		}
+		separator()
+		return buf.String()
+	}
+
+	// 无边框时列间以两个空格分隔，表头下方以 '-' 分隔，并去除行尾空白
+	var line strings.Builder
+	writeLine := func(cells []string, pad func(col int, cell string)) {
+		line.Reset()
+		for col, cell := range cells {
+			if col > 0 {
+				line.WriteString("  ")
+			}
+			pad(col, cell)
+		}
+		buf.WriteString(strings.TrimRight(line.String(), " "))
+		buf.WriteByte('\n')
+	}
+	for i, row := range rows {
+		writeLine(row, func(col int, cell string) {
+			writePadded(&line, cell, widths[col], t.align(col))
+		})
+		if i == 0 && len(t.Headers) > 0 {
+			writeLine(row, func(col int, _ string) {
+				line.WriteString(strings.Repeat("-", widths[col]))
+			})
+		}
+	}
+	return buf.String()
+}
+
+func (t *Table) renderMarkdown() string {
+	columns := t.numColumns()
+	if columns == 0 {
+		return ""
+	}
+
+	// 单元格内的 '|' 需要转义，换行以 <br> 表示
+	escape := joinLines("<br>")
+	transform := func(cell string) string {
+		return strings.ReplaceAll(escape(cell), "|", `\|`)
+	}
+	rows := make([][]string, 0, len(t.Rows)+1)
+	rows = append(rows, normalizeRow(t.Headers, columns, transform))
+	for _, row := range t.Rows {
+		rows = append(rows, normalizeRow(row, columns, transform))
+	}
+	widths := columnWidths(rows, columns, 3)
+
+	var buf strings.Builder
+	for i, row := range rows {
+		for col, cell := range row {
+			buf.WriteString("| ")
+			writePadded(&buf, cell, widths[col], t.align(col))
+			buf.WriteByte(' ')
+		}
+		buf.WriteString("|\n")
+
+		// 表头分隔行，以 ':' 标识对齐方式
+		if i == 0 {
+			for col, width := range widths {
+				buf.WriteString("| ")
+				switch t.align(col) {
+				case AlignRight:
+					buf.WriteString(strings.Repeat("-", width-1))
+					buf.WriteByte(':')
+				case AlignCenter:
+					buf.WriteByte(':')
+					buf.WriteString(strings.Repeat("-", width-2))
+					buf.WriteByte(':')
+				default:
+					buf.WriteString(strings.Repeat("-", width))
+				}
+				buf.WriteByte(' ')
+			}
+			buf.WriteString("|\n")
+		}
+	}
+	return buf.String()
+}
+
+func (t *Table) renderCSV() string {
+	columns := t.numColumns()
+	if columns == 0 {
+		return ""
+	}
+
+	identity := func(cell string) string { return cell }
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+	if len(t.Headers) > 0 {
+		_ = w.Write(normalizeRow(t.Headers, columns, identity))
+	}
+	for _, row := range t.Rows {
+		_ = w.Write(normalizeRow(row, columns, identity))
+	}
+	w.Flush()
+	return buf.String()
+}
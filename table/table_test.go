@@ -0,0 +1,115 @@
+package table
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestTableRender(t *testing.T) {
+	newTable := func() *Table {
+		return New("Name", "Count", "Note").
+			SetAligns(AlignLeft, AlignRight, AlignCenter).
+			AddRow("foo", "1", "ok").
+			AddRow("中文", "12345", "a|b")
+	}
+
+	tests := []struct {
+		name   string
+		table  *Table
+		format Format
+		want   string
+	}{
+		{
+			name:   "text",
+			table:  newTable(),
+			format: FormatText,
+			want: "" +
+				"Name  Count  Note\n" +
+				"----  -----  ----\n" +
+				"foo       1   ok\n" +
+				"中文  12345  a|b\n",
+		},
+		{
+			name:   "text with border",
+			table:  newTable().SetBorder(true),
+			format: FormatText,
+			want: "" +
+				"+------+-------+------+\n" +
+				"| Name | Count | Note |\n" +
+				"+------+-------+------+\n" +
+				"| foo  |     1 |  ok  |\n" +
+				"| 中文 | 12345 | a|b  |\n" +
+				"+------+-------+------+\n",
+		},
+		{
+			name:   "markdown",
+			table:  newTable(),
+			format: FormatMarkdown,
+			want: "" +
+				"| Name | Count | Note |\n" +
+				"| ---- | ----: | :--: |\n" +
+				"| foo  |     1 |  ok  |\n" +
+				"| 中文 | 12345 | a\\|b |\n",
+		},
+		{
+			name:   "csv",
+			table:  newTable().AddRow("x,y", `"q"`),
+			format: FormatCSV,
+			want: "" +
+				"Name,Count,Note\n" +
+				"foo,1,ok\n" +
+				"中文,12345,a|b\n" +
+				"\"x,y\",\"\"\"q\"\"\",\n",
+		},
+		{
+			name:   "no headers and ragged rows",
+			table:  New().AddRow("a").AddRow("b", "c\nd"),
+			format: FormatText,
+			want: "" +
+				"a\n" +
+				"b  c d\n",
+		},
+		{
+			name:   "markdown without headers",
+			table:  New().AddRow("a", "b"),
+			format: FormatMarkdown,
+			want: "" +
+				"|     |     |\n" +
+				"| --- | --- |\n" +
+				"| a   | b   |\n",
+		},
+		{
+			name:   "empty",
+			table:  New(),
+			format: FormatText,
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.Render(tt.format); got != tt.want {
+				t.Errorf("Render() = \n%s\nwant \n%s", strconv.Quote(got), strconv.Quote(tt.want))
+			}
+		})
+	}
+}
+
+func TestAddRowsFunc(t *testing.T) {
+	type item struct {
+		name  string
+		count int
+	}
+	items := []item{{"a", 1}, {"bb", 22}}
+
+	tbl := AddRowsFunc(New("name", "count"), items, func(it item) []string {
+		return []string{it.name, strconv.Itoa(it.count)}
+	})
+	want := "" +
+		"name  count\n" +
+		"----  -----\n" +
+		"a     1\n" +
+		"bb    22\n"
+	if got := tbl.String(); got != want {
+		t.Errorf("String() = %s, want %s", strconv.Quote(got), strconv.Quote(want))
+	}
+}
@@ -0,0 +1,96 @@
+package urlenc
+
+// PHP URL 编码函数 urlencode()、rawurlencode()、urldecode()、rawurldecode() 的实现
+// 与标准库 net/url 的区别:
+// - Encode 与 PHP urlencode() 一致(application/x-www-form-urlencoded 编码，RFC 1866): 空格编码为 '+'，'~' 编码为 "%7E"；url.QueryEscape 不编码 '~'
+// - RawEncode 与 PHP rawurlencode() 一致(RFC 3986): 只保留字母、数字及 "-._~"；url.PathEscape 还会保留 "!$&'()*+,;=:@" 等字符
+// - 解码时不合法的 '%' 序列原样保留而不是返回错误，e.g. "%zz" => "%zz"、"100%" => "100%"
+// 所有函数均按字节处理，支持任意二进制输入
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+)
+
+const upperHex = "0123456789ABCDEF"
+
+// shouldKeep 判断字节是否无需编码
+func shouldKeep(c byte, raw bool) bool {
+	return ascii.IsAlphaNum(c) || c == '-' || c == '.' || c == '_' || (raw && c == '~')
+}
+
+// Encode URL 编码，对应 PHP 函数 urlencode()
+// 除字母、数字及 "-._" 外的字节均编码为 "%XX"(大写十六进制)，空格编码为 '+'
+func Encode[S ~string | ~[]byte](s S) string {
+	return encode(s, false)
+}
+
+// RawEncode 按 RFC 3986 进行 URL 编码，对应 PHP 函数 rawurlencode()
+// 除字母、数字及 "-._~" 外的字节均编码为 "%XX"(大写十六进制)，空格编码为 "%20"
+func RawEncode[S ~string | ~[]byte](s S) string {
+	return encode(s, true)
+}
+
+func encode[S ~string | ~[]byte](s S, raw bool) string {
+	// 预计算结果尺寸，无需编码时直接返回
+	size, changed := len(s), false
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !shouldKeep(c, raw) {
+			changed = true
+			if raw || c != ' ' {
+				size += 2
+			}
+		}
+	}
+	if !changed {
+		return string(s)
+	}
+
+	buf := make([]byte, 0, size)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case shouldKeep(c, raw):
+			buf = append(buf, c)
+		case c == ' ' && !raw:
+			buf = append(buf, '+')
+		default:
+			buf = append(buf, '%', upperHex[c>>4], upperHex[c&0xf])
+		}
+	}
+	return string(buf)
+}
+
+// Decode URL 解码，对应 PHP 函数 urldecode()
+// '+' 解码为空格，"%XX"(不区分大小写)解码为对应字节，不合法的 '%' 序列原样保留
+func Decode[S ~string | ~[]byte](s S) string {
+	return decode(s, false)
+}
+
+// RawDecode URL 解码，对应 PHP 函数 rawurldecode()
+// 与 Decode 的区别是 '+' 保持不变
+func RawDecode[S ~string | ~[]byte](s S) string {
+	return decode(s, true)
+}
+
+func decode[S ~string | ~[]byte](s S, raw bool) string {
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '+' && !raw:
+			buf = append(buf, ' ')
+		case c == '%' && i+2 < len(s):
+			hi, ok1 := ascii.ParseXDigit(s[i+1])
+			lo, ok2 := ascii.ParseXDigit(s[i+2])
+			if ok1 && ok2 {
+				buf = append(buf, hi<<4|lo)
+				i += 2
+			} else {
+				buf = append(buf, c)
+			}
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return string(buf)
+}
@@ -0,0 +1,83 @@
+package urlenc
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantRaw string
+	}{
+		{"", "", ""},
+		{"abcXYZ019", "abcXYZ019", "abcXYZ019"},
+		{"-._~", "-._%7E", "-._~"},
+		{"a b+c", "a+b%2Bc", "a%20b%2Bc"},
+		{"foo@bar.com", "foo%40bar.com", "foo%40bar.com"},
+		{"!*'();:@&=+$,/?#[]", "%21%2A%27%28%29%3B%3A%40%26%3D%2B%24%2C%2F%3F%23%5B%5D", "%21%2A%27%28%29%3B%3A%40%26%3D%2B%24%2C%2F%3F%23%5B%5D"},
+		{"\x00\x7f\x80\xff", "%00%7F%80%FF", "%00%7F%80%FF"},
+		{"中", "%E4%B8%AD", "%E4%B8%AD"},
+	}
+	for _, tt := range tests {
+		if got := Encode(tt.s); got != tt.want {
+			t.Errorf("Encode(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		if got := RawEncode([]byte(tt.s)); got != tt.wantRaw {
+			t.Errorf("RawEncode(%q) = %q, want %q", tt.s, got, tt.wantRaw)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantRaw string
+	}{
+		{"", "", ""},
+		{"a+b%20c", "a b c", "a+b c"},
+		{"%41%4a%4A", "AJJ", "AJJ"},
+		{"%E4%B8%AD", "中", "中"},
+		{"%00%ff", "\x00\xff", "\x00\xff"},
+		// 不合法的 '%' 序列原样保留
+		{"100%", "100%", "100%"},
+		{"%", "%", "%"},
+		{"%4", "%4", "%4"},
+		{"%zz", "%zz", "%zz"},
+		{"%4g%41", "%4gA", "%4gA"},
+		{"%%41", "%A", "%A"},
+		{"%+41", "% 41", "%+41"},
+		{"%2", "%2", "%2"},
+		{"%2B", "+", "+"},
+	}
+	for _, tt := range tests {
+		if got := Decode(tt.s); got != tt.want {
+			t.Errorf("Decode(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		if got := RawDecode([]byte(tt.s)); got != tt.wantRaw {
+			t.Errorf("RawDecode(%q) = %q, want %q", tt.s, got, tt.wantRaw)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var all strings.Builder
+	for c := 0; c < 256; c++ {
+		all.WriteByte(byte(c))
+	}
+	s := all.String()
+
+	if got := Decode(Encode(s)); got != s {
+		t.Errorf("Decode(Encode()) round trip failed: %q", got)
+	}
+	if got := RawDecode(RawEncode(s)); got != s {
+		t.Errorf("RawDecode(RawEncode()) round trip failed: %q", got)
+	}
+	// 除 '~' 外与 url.QueryEscape 的结果一致
+	if got, want := Encode(s), strings.ReplaceAll(url.QueryEscape(s), "~", "%7E"); got != want {
+		t.Errorf("Encode() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package xbytes
+
+// 本文件内是处理大小写相关的函数，约定与 xstrings 一致:
+// - 大小写转换只处理 ASCII 范围内的字符，对之外的字符保持不变。与标准库函数(bytes.ToLower/bytes.ToUpper等)逻辑不同。
+// - 分词时将非 ASCII 字符作为单独类型，不同 ASCII 间不做区分。e.g. "用户のID" 会分词为 "用户の" + "ID"
+
+import (
+	"bytes"
+	"github.com/heyuuu/gophp-utils/ascii"
+	"github.com/heyuuu/gophp-utils/internal/asciicase"
+)
+
+// ToUpperInPlace 原地转大写，不分配新内存
+func ToUpperInPlace(s []byte) {
+	for i, c := range s {
+		s[i] = ascii.ToUpper(c)
+	}
+}
+
+// ToLowerInPlace 原地转小写，不分配新内存
+func ToLowerInPlace(s []byte) {
+	for i, c := range s {
+		s[i] = ascii.ToLower(c)
+	}
+}
+
+// ToUpper 转大写，返回新切片
+// 与标准库 bytes.ToUpper() 的区别是，它不处理除英文字母外的其他unicode字母
+func ToUpper(s []byte) []byte {
+	buf := bytes.Clone(s)
+	ToUpperInPlace(buf)
+	return buf
+}
+
+// IsUpper 判断是否全大写
+func IsUpper(s []byte) bool {
+	for _, c := range s {
+		if ascii.IsLower(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToLower 转小写，返回新切片
+// 与标准库 bytes.ToLower() 的区别是，它不处理除英文字母外的其他unicode字母
+func ToLower(s []byte) []byte {
+	buf := bytes.Clone(s)
+	ToLowerInPlace(buf)
+	return buf
+}
+
+// IsLower 判断是否全小写
+func IsLower(s []byte) bool {
+	for _, c := range s {
+		if ascii.IsUpper(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Capitalize 首字母大写，其他字母小写
+func Capitalize(s []byte) []byte {
+	buf := bytes.Clone(s)
+	ToLowerInPlace(buf)
+	if len(buf) > 0 {
+		buf[0] = ascii.ToUpper(buf[0])
+	}
+	return buf
+}
+
+// UpperFirst 首字母大写
+func UpperFirst(s []byte) []byte {
+	buf := bytes.Clone(s)
+	if len(buf) > 0 {
+		buf[0] = ascii.ToUpper(buf[0])
+	}
+	return buf
+}
+
+// LowerFirst 首字母小写
+func LowerFirst(s []byte) []byte {
+	buf := bytes.Clone(s)
+	if len(buf) > 0 {
+		buf[0] = ascii.ToLower(buf[0])
+	}
+	return buf
+}
+
+// CompareFold 比较字节切片，忽略大小写
+// 与标准库 bytes.Compare() 的区别是，它忽略英文字母的大小写
+func CompareFold(s1 []byte, s2 []byte) int {
+	l := min(len(s1), len(s2))
+	for i := 0; i < l; i++ {
+		c1, c2 := ascii.ToLower(s1[i]), ascii.ToLower(s2[i])
+		if c1 == c2 {
+			continue
+		} else if c1 < c2 {
+			return -1
+		} else {
+			return 1
+		}
+	}
+	if l < len(s1) {
+		return 1
+	}
+	if l < len(s2) {
+		return -1
+	}
+	return 0
+}
+
+// EqualFold 比较字节切片是否相等，忽略大小写
+// 与标准库 bytes.EqualFold() 的区别是，它不处理除英文字母外的其他unicode字母
+func EqualFold(s1 []byte, s2 []byte) bool {
+	return len(s1) == len(s2) && CompareFold(s1, s2) == 0
+}
+
+// HasPrefixFold 判断是否有指定前缀，忽略大小写
+func HasPrefixFold(s []byte, prefix []byte) bool {
+	return len(s) >= len(prefix) && CompareFold(s[:len(prefix)], prefix) == 0
+}
+
+// HasSuffixFold 判断是否有指定后缀，忽略大小写
+func HasSuffixFold(s []byte, suffix []byte) bool {
+	return len(s) >= len(suffix) && CompareFold(s[len(s)-len(suffix):], suffix) == 0
+}
+
+// commonCase 通用的case处理函数
+func commonCase(s []byte, sep string, caseHandler func(i int, word []byte)) []byte {
+	buf := asciicase.JoinWords(asciicase.SplitWords(s), sep, caseHandler)
+	if buf == nil {
+		return []byte{}
+	}
+	return buf
+}
+
+// CamelCase 驼峰命名法(又称小驼峰命名法)，e.g. "userName"
+func CamelCase(s []byte) []byte {
+	return commonCase(s, "", asciicase.CamelWord)
+}
+
+// PascalCase 帕斯卡命名法(又称大驼峰命名法)，e.g. "UserName"
+func PascalCase(s []byte) []byte {
+	return commonCase(s, "", asciicase.PascalWord)
+}
+
+// SnakeCase 蛇型命名法，e.g. "user_name"
+func SnakeCase(s []byte) []byte {
+	return commonCase(s, "_", asciicase.LowerWord)
+}
+
+// ScreamingSnakeCase 大蛇型命名法，e.g. "USER_NAME"
+func ScreamingSnakeCase(s []byte) []byte {
+	return commonCase(s, "_", asciicase.UpperWord)
+}
+
+// KebabCase 烤串式命名法，e.g. "user-name"
+func KebabCase(s []byte) []byte {
+	return commonCase(s, "-", asciicase.LowerWord)
+}
+
+// ScreamingKebabCase 大烤串式命名法，e.g. "USER-NAME"
+func ScreamingKebabCase(s []byte) []byte {
+	return commonCase(s, "-", asciicase.UpperWord)
+}
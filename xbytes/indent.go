@@ -0,0 +1,84 @@
+package xbytes
+
+import (
+	"bytes"
+	"github.com/heyuuu/gophp-utils/ascii"
+)
+
+// indentWidth 返回行首空白字符的长度
+func indentWidth(s []byte) int {
+	if i := ascii.IndexNonSpace(s); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+// TrimIndent 去除多行文本的前置缩进，返回新切片，规则与 xstrings.TrimIndent() 一致
+// 去除缩进长度为所有非空白行缩进长度的最小值；空白行缩进长度不够时置空；若首尾行为空行则移除
+func TrimIndent(s []byte) []byte {
+	lines := splitLines(s)
+
+	// 计算共同缩进长度
+	commonIndent := -1
+	for _, l := range lines {
+		// 跳过空白行
+		if IsBlank(l.line) {
+			continue
+		}
+
+		// 非空白行计算共同缩进长度
+		indent := indentWidth(l.line)
+		if commonIndent < 0 {
+			commonIndent = indent
+		} else {
+			commonIndent = min(commonIndent, indent)
+		}
+	}
+
+	// 无需修改缩进时，直接返回原值的副本
+	if commonIndent <= 0 {
+		return bytes.Clone(s)
+	}
+
+	// 首行和末行若为空白行，则移除
+	if len(lines) > 0 && IsBlank(lines[0].line) {
+		lines = lines[1:]
+	}
+	if len(lines) > 0 && IsBlank(lines[len(lines)-1].line) {
+		lines = lines[:len(lines)-1]
+	}
+
+	// 逐行修改，末行不保留换行符
+	buf := make([]byte, 0, len(s))
+	for i, l := range lines {
+		if commonIndent < len(l.line) {
+			buf = append(buf, l.line[commonIndent:]...)
+		}
+		if i < len(lines)-1 {
+			buf = append(buf, l.newline...)
+		}
+	}
+	return buf
+}
+
+// PrependIndent 给多行文本添加同一个前缀，返回新切片，规则与 xstrings.PrependIndent() 一致
+func PrependIndent(s []byte, prefix []byte) []byte {
+	if len(prefix) == 0 {
+		return bytes.Clone(s)
+	}
+
+	lines := splitLines(s)
+	style, _ := DetectNewline(s)
+
+	buf := make([]byte, 0, len(s)+len(lines)*(len(prefix)+1))
+	for _, l := range lines {
+		buf = append(buf, prefix...)
+		buf = append(buf, l.line...)
+		if len(l.newline) > 0 {
+			buf = append(buf, l.newline...)
+		} else {
+			buf = append(buf, style.String()...)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,84 @@
+package xbytes
+
+// 本文件内是按行处理相关的函数，同时识别 "\n"、"\r\n"、"\r" 三种换行符，与 xstrings 一致
+
+import (
+	"bytes"
+	"github.com/heyuuu/gophp-utils/xstrings"
+	"iter"
+)
+
+// nextLine 查找 s 中第一个换行符，返回行内容长度和换行符长度；无换行符时返回 len(s), 0
+func nextLine(s []byte) (lineLen int, termLen int) {
+	i := bytes.IndexAny(s, "\r\n")
+	if i < 0 {
+		return len(s), 0
+	}
+	if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+		return i, 2
+	}
+	return i, 1
+}
+
+// Lines 按行遍历，依次返回每行内容(不含换行符)及其换行符
+// 最后一行无换行符时返回的换行符为空切片；以换行符结尾时，不会额外返回末尾的空行
+// 返回值均为原切片的子切片，遍历过程中不会分配内存
+func Lines(s []byte) iter.Seq2[[]byte, []byte] {
+	return func(yield func(line []byte, newline []byte) bool) {
+		for len(s) > 0 {
+			lineLen, termLen := nextLine(s)
+			if !yield(s[:lineLen:lineLen], s[lineLen:lineLen+termLen:lineLen+termLen]) {
+				return
+			}
+			s = s[lineLen+termLen:]
+		}
+	}
+}
+
+// DetectNewline 检测使用的换行符风格，以第一个出现的换行符为准
+// 不含换行符时返回 xstrings.NewlineLF, false
+func DetectNewline(s []byte) (xstrings.NewlineStyle, bool) {
+	lineLen, termLen := nextLine(s)
+	switch {
+	case termLen == 0:
+		return xstrings.NewlineLF, false
+	case termLen == 2:
+		return xstrings.NewlineCRLF, true
+	case s[lineLen] == '\r':
+		return xstrings.NewlineCR, true
+	default:
+		return xstrings.NewlineLF, true
+	}
+}
+
+// NormalizeNewlines 将所有换行符统一替换为指定风格，返回新切片
+func NormalizeNewlines(s []byte, style xstrings.NewlineStyle) []byte {
+	newline := style.String()
+
+	buf := make([]byte, 0, len(s))
+	for line, term := range Lines(s) {
+		buf = append(buf, line...)
+		if len(term) > 0 {
+			buf = append(buf, newline...)
+		}
+	}
+	return buf
+}
+
+// lineEntry 行内容及其换行符
+type lineEntry struct {
+	line    []byte
+	newline []byte
+}
+
+// splitLines 按行切分，结果与 bytes.Split(s, "\n") 对应：以换行符结尾时末尾包含一个空行
+func splitLines(s []byte) []lineEntry {
+	var lines []lineEntry
+	for line, term := range Lines(s) {
+		lines = append(lines, lineEntry{line, term})
+	}
+	if len(lines) == 0 || len(lines[len(lines)-1].newline) > 0 {
+		lines = append(lines, lineEntry{})
+	}
+	return lines
+}
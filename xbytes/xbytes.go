@@ -0,0 +1,93 @@
+package xbytes
+
+// 本包是标准库 bytes 的补充，提供与 xstrings 相同的 API，但操作对象为 []byte
+// 除 *InPlace 系列函数外，返回 []byte 的函数都不会修改参数，且返回值不与参数共享内存
+
+import (
+	"bytes"
+	"github.com/heyuuu/gophp-utils/ascii"
+)
+
+// IsBlank 判断是否为空或只包含 ASCII 空白字符(" \t\n\v\f\r")
+func IsBlank(s []byte) bool {
+	return ascii.IndexNonSpace(s) < 0
+}
+
+func PadLeft(s []byte, size int, pad byte) []byte {
+	if len(s) >= size {
+		return bytes.Clone(s)
+	}
+
+	buf := make([]byte, size)
+	padSize := size - len(s)
+	copy(buf[padSize:], s)
+	for i := 0; i < padSize; i++ {
+		buf[i] = pad
+	}
+	return buf
+}
+
+func PadRight(s []byte, size int, pad byte) []byte {
+	if len(s) >= size {
+		return bytes.Clone(s)
+	}
+
+	buf := make([]byte, size)
+	copy(buf, s)
+	for i := len(s); i < size; i++ {
+		buf[i] = pad
+	}
+	return buf
+}
+
+// LastCut
+// 类似 bytes.Cut()，但是是从尾部反向开始查找的
+func LastCut(s []byte, sep []byte) (before, after []byte, found bool) {
+	if i := bytes.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, nil, false
+}
+
+// Join
+// bytes.Join() 的别名占位
+func Join(elems [][]byte, sep []byte) []byte {
+	return bytes.Join(elems, sep)
+}
+
+// JoinFunc
+// 类似 bytes.Join()，但是支持非 []byte 类型列表+转换函数
+func JoinFunc[T any](elems []T, sep []byte, transform func(T) []byte) []byte {
+	if len(elems) == 0 {
+		return []byte{}
+	}
+
+	var buf bytes.Buffer
+	for i, elem := range elems {
+		if i > 0 {
+			buf.Write(sep)
+		}
+		buf.Write(transform(elem))
+	}
+	return buf.Bytes()
+}
+
+func ReverseJoin(elems [][]byte, sep []byte) []byte {
+	if len(elems) == 0 {
+		return []byte{}
+	}
+
+	size := (len(elems) - 1) * len(sep)
+	for _, elem := range elems {
+		size += len(elem)
+	}
+
+	buf := make([]byte, 0, size)
+	for i := len(elems) - 1; i >= 0; i-- {
+		buf = append(buf, elems[i]...)
+		if i > 0 {
+			buf = append(buf, sep...)
+		}
+	}
+	return buf
+}
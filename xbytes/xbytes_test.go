@@ -0,0 +1,133 @@
+package xbytes
+
+import (
+	"github.com/heyuuu/gophp-utils/xstrings"
+	"strconv"
+	"testing"
+)
+
+// 测试用例，覆盖空串、大小写混合、非 ASCII、各类换行符
+var equivalenceInputs = []string{
+	"",
+	" ",
+	"Simple WORD",
+	"_wORD",
+	"用户のiD",
+	"HTTPServer",
+	"wordWith01number",
+	"\tline1\n\t\tline2\n",
+	"\r\n\tline1\r\n\t\tline2\r\tline3\n",
+	"\xff\x80abc",
+}
+
+func TestEquivalence(t *testing.T) {
+	stringFuncs := map[string][2]func(string) string{
+		"ToUpper":            {xstrings.ToUpper, wrap(ToUpper)},
+		"ToLower":            {xstrings.ToLower, wrap(ToLower)},
+		"Capitalize":         {xstrings.Capitalize, wrap(Capitalize)},
+		"UpperFirst":         {xstrings.UpperFirst, wrap(UpperFirst)},
+		"LowerFirst":         {xstrings.LowerFirst, wrap(LowerFirst)},
+		"CamelCase":          {xstrings.CamelCase, wrap(CamelCase)},
+		"PascalCase":         {xstrings.PascalCase, wrap(PascalCase)},
+		"SnakeCase":          {xstrings.SnakeCase, wrap(SnakeCase)},
+		"ScreamingSnakeCase": {xstrings.ScreamingSnakeCase, wrap(ScreamingSnakeCase)},
+		"KebabCase":          {xstrings.KebabCase, wrap(KebabCase)},
+		"ScreamingKebabCase": {xstrings.ScreamingKebabCase, wrap(ScreamingKebabCase)},
+		"TrimIndent":         {xstrings.TrimIndent, wrap(TrimIndent)},
+		"PrependIndent": {
+			func(s string) string { return xstrings.PrependIndent(s, "> ") },
+			func(s string) string { return string(PrependIndent([]byte(s), []byte("> "))) },
+		},
+		"NormalizeNewlines": {
+			func(s string) string { return xstrings.NormalizeNewlines(s, xstrings.NewlineCRLF) },
+			func(s string) string { return string(NormalizeNewlines([]byte(s), xstrings.NewlineCRLF)) },
+		},
+		"PadLeft": {
+			func(s string) string { return xstrings.PadLeft(s, 12, '0') },
+			func(s string) string { return string(PadLeft([]byte(s), 12, '0')) },
+		},
+		"PadRight": {
+			func(s string) string { return xstrings.PadRight(s, 12, '0') },
+			func(s string) string { return string(PadRight([]byte(s), 12, '0')) },
+		},
+	}
+	boolFuncs := map[string][2]func(string) bool{
+		"IsBlank": {xstrings.IsBlank, wrapBool(IsBlank)},
+		"IsUpper": {xstrings.IsUpper, wrapBool(IsUpper)},
+		"IsLower": {xstrings.IsLower, wrapBool(IsLower)},
+	}
+
+	for _, s := range equivalenceInputs {
+		for name, fns := range stringFuncs {
+			if want, got := fns[0](s), fns[1](s); got != want {
+				t.Errorf("%s(%s) = %s, want %s", name, strconv.Quote(s), strconv.Quote(got), strconv.Quote(want))
+			}
+		}
+		for name, fns := range boolFuncs {
+			if want, got := fns[0](s), fns[1](s); got != want {
+				t.Errorf("%s(%s) = %v, want %v", name, strconv.Quote(s), got, want)
+			}
+		}
+		for _, s2 := range equivalenceInputs {
+			if want, got := xstrings.CompareFold(s, s2), CompareFold([]byte(s), []byte(s2)); got != want {
+				t.Errorf("CompareFold(%s, %s) = %v, want %v", strconv.Quote(s), strconv.Quote(s2), got, want)
+			}
+			if want, got := xstrings.HasPrefixFold(s, s2), HasPrefixFold([]byte(s), []byte(s2)); got != want {
+				t.Errorf("HasPrefixFold(%s, %s) = %v, want %v", strconv.Quote(s), strconv.Quote(s2), got, want)
+			}
+		}
+	}
+}
+
+func wrap(f func([]byte) []byte) func(string) string {
+	return func(s string) string { return string(f([]byte(s))) }
+}
+
+func wrapBool(f func([]byte) bool) func(string) bool {
+	return func(s string) bool { return f([]byte(s)) }
+}
+
+func TestToUpperInPlace(t *testing.T) {
+	buf := []byte("用户のiD word")
+	ToUpperInPlace(buf)
+	if got, want := string(buf), "用户のID WORD"; got != want {
+		t.Errorf("ToUpperInPlace() = %v, want %v", got, want)
+	}
+	ToLowerInPlace(buf)
+	if got, want := string(buf), "用户のid word"; got != want {
+		t.Errorf("ToLowerInPlace() = %v, want %v", got, want)
+	}
+}
+
+func TestNoAliasing(t *testing.T) {
+	src := []byte("word")
+	got := ToLower(src)
+	got[0] = 'W'
+	if string(src) != "word" {
+		t.Errorf("ToLower() result shares memory with argument")
+	}
+}
+
+func TestReverseJoin(t *testing.T) {
+	tests := []struct {
+		name  string
+		elems []string
+		sep   string
+		want  string
+	}{
+		{"", nil, ",", ""},
+		{"", []string{"a"}, ",", "a"},
+		{"", []string{"a", "b", "c"}, "::", "c::b::a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elems := make([][]byte, len(tt.elems))
+			for i, elem := range tt.elems {
+				elems[i] = []byte(elem)
+			}
+			if got := ReverseJoin(elems, []byte(tt.sep)); string(got) != tt.want {
+				t.Errorf("ReverseJoin() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
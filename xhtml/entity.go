@@ -0,0 +1,83 @@
+package xhtml
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// entitiesData 命名字符引用表，格式见文件头部注释
+//
+//go:embed entities.txt
+var entitiesData string
+
+// entityTables 由 entitiesData 生成的命名字符引用表，键为不含 '&' 和 ';' 的名称，值为 UTF-8 编码的字符
+type entityTables struct {
+	html5 map[string]string
+	html4 map[string]string
+}
+
+// basicEntities XML 1.0 预定义的字符引用，对应 PHP 中 ENT_XML1 文档类型使用的表
+var basicEntities = map[string]string{
+	"amp":  "&",
+	"lt":   "<",
+	"gt":   ">",
+	"quot": "\"",
+	"apos": "'",
+}
+
+var loadEntityTables = sync.OnceValue(func() *entityTables {
+	tables := &entityTables{
+		html5: make(map[string]string, 2200),
+		html4: make(map[string]string, 256),
+	}
+	for line := range strings.Lines(entitiesData) {
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		name := fields[0]
+		tables.html5[name] = decodeCodePoints(fields[1])
+		if len(fields) > 2 {
+			tables.html4[name] = decodeCodePoints(fields[2])
+		}
+	}
+	return tables
+})
+
+// decodeCodePoints 将空格分隔的十六进制码点转为 UTF-8 字符串，数据文件格式错误时 panic
+func decodeCodePoints(s string) string {
+	var buf []byte
+	for _, field := range strings.Fields(s) {
+		cp, err := strconv.ParseUint(field, 16, 32)
+		if err != nil {
+			panic("xhtml: invalid entities data: " + err.Error())
+		}
+		buf = utf8.AppendRune(buf, rune(cp))
+	}
+	return string(buf)
+}
+
+// lookupEntity 按文档类型查找命名字符引用，对应 PHP 源码中 html_entity_decode() 使用的 inverse map
+// 与 PHP 一致，XHTML 使用 HTML 4.01 的表，但额外支持 &apos;
+func lookupEntity(name string, doctype Flags) (string, bool) {
+	var table map[string]string
+	switch doctype {
+	case EntHTML401:
+		table = loadEntityTables().html4
+	case EntXHTML:
+		if name == "apos" {
+			return "'", true
+		}
+		table = loadEntityTables().html4
+	case EntHTML5:
+		table = loadEntityTables().html5
+	default:
+		table = basicEntities
+	}
+	s, ok := table[name]
+	return s, ok
+}
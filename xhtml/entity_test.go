@@ -0,0 +1,30 @@
+package xhtml
+
+import (
+	"html"
+	"testing"
+)
+
+func TestEntityTables(t *testing.T) {
+	tables := loadEntityTables()
+	if len(tables.html5) != 2125 || len(tables.html4) != 252 {
+		t.Fatalf("len(html5) = %d, len(html4) = %d", len(tables.html5), len(tables.html4))
+	}
+
+	// HTML5 表与标准库 html 包一致(标准库不支持 &nLt; 和 &nGt;)
+	for name, want := range tables.html5 {
+		ref := "&" + name + ";"
+		if got := html.UnescapeString(ref); got != want && name != "nLt" && name != "nGt" {
+			t.Errorf("html.UnescapeString(%q) = %q, want %q", ref, got, want)
+		}
+		if got := EntityDecode(ref, EntQuotes|EntHTML5); got != want {
+			t.Errorf("EntityDecode(%q) = %q, want %q", ref, got, want)
+		}
+	}
+	// HTML 4.01 的名称均属于 HTML5
+	for name := range tables.html4 {
+		if _, ok := tables.html5[name]; !ok {
+			t.Errorf("HTML 4.01 entity %q not in HTML5 table", name)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package xhtml
+
+// PHP HTML 转义相关函数 htmlspecialchars()、html_entity_decode()、strip_tags() 的实现
+// 只支持 UTF-8 编码；与标准库 html.EscapeString、html.UnescapeString 不同，转义方式可通过 Flags 配置
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"strings"
+	"unicode/utf8"
+)
+
+// Flags 转义选项，取值及含义与 PHP 中的 ENT_* 常量一致
+type Flags int
+
+const (
+	quoteSingle Flags = 1
+	quoteDouble Flags = 2
+
+	EntNoQuotes   Flags = 0                         // 不处理引号
+	EntCompat     Flags = quoteDouble               // 只处理双引号
+	EntQuotes     Flags = quoteSingle | quoteDouble // 处理单引号和双引号
+	EntIgnore     Flags = 4                         // 丢弃无效的 UTF-8 序列
+	EntSubstitute Flags = 8                         // 将无效的 UTF-8 序列替换为 U+FFFD
+	EntDisallowed Flags = 128                       // 将文档类型中不允许的字符替换为 U+FFFD
+
+	// 文档类型，互斥
+	EntHTML401 Flags = 0
+	EntXML1    Flags = 16
+	EntXHTML   Flags = 32
+	EntHTML5   Flags = 48
+
+	docTypeMask Flags = 48
+)
+
+// DefaultFlags PHP 8.1 起 htmlspecialchars()、html_entity_decode() 的默认选项
+const DefaultFlags = EntQuotes | EntSubstitute | EntHTML401
+
+const replacementChar = "\uFFFD"
+
+// SpecialChars 转义 HTML 特殊字符，对应 PHP 函数 htmlspecialchars()
+// - '&'、'<'、'>' 总是转义；双引号在 EntCompat 时转义；单引号在 EntQuotes 时转义，HTML 4.01 中为 "&#039;"，其他文档类型中为 "&apos;"
+// - doubleEncode 为 false 时，已有的合法字符引用(按文档类型判断)不再转义
+// - 存在无效的 UTF-8 序列且未设置 EntIgnore、EntSubstitute 时返回空字符串
+func SpecialChars(s string, flags Flags, doubleEncode bool) string {
+	doctype := flags & docTypeMask
+
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for i := 0; i < len(s); {
+		c, size, ok := nextChar(s, i)
+		seq := s[i : i+size]
+		i += size
+		if !ok {
+			switch {
+			case flags&EntIgnore != 0:
+			case flags&EntSubstitute != 0:
+				buf.WriteString(replacementChar)
+			default:
+				return ""
+			}
+			continue
+		}
+
+		switch {
+		case c == '&':
+			if !doubleEncode {
+				if n := validReference(s[i:], flags); n > 0 {
+					buf.WriteByte('&')
+					buf.WriteString(s[i : i+n])
+					i += n
+					continue
+				}
+			}
+			buf.WriteString("&amp;")
+		case c == '<':
+			buf.WriteString("&lt;")
+		case c == '>':
+			buf.WriteString("&gt;")
+		case c == '"':
+			if flags&quoteDouble != 0 {
+				buf.WriteString("&quot;")
+			} else {
+				buf.WriteByte('"')
+			}
+		case c == '\'':
+			switch {
+			case flags&quoteSingle == 0:
+				buf.WriteByte('\'')
+			case doctype == EntHTML401:
+				buf.WriteString("&#039;")
+			default:
+				buf.WriteString("&apos;")
+			}
+		case flags&EntDisallowed != 0 && !isAllowedChar(c, doctype):
+			buf.WriteString(replacementChar)
+		default:
+			buf.WriteString(seq)
+		}
+	}
+	return buf.String()
+}
+
+// validReference 判断 '&' 之后的 s 是否以合法的字符引用开头，返回引用的长度(含结尾的 ';')，不合法时返回 0
+func validReference(s string, flags Flags) int {
+	doctype := flags & docTypeMask
+	if len(s) > 0 && s[0] == '#' {
+		cp, end, ok := parseNumericReference(s, 1)
+		if !ok || (flags&EntDisallowed != 0 && !isAllowedNumericReference(cp, doctype)) {
+			return 0
+		}
+		return end + 1
+	}
+
+	end, ok := scanEntityName(s, 0)
+	if !ok {
+		return 0
+	}
+	if _, ok := lookupEntity(s[:end], doctype); !ok {
+		return 0
+	}
+	return end + 1
+}
+
+// EntityDecode 将字符引用解码为对应字符，对应 PHP 函数 html_entity_decode()
+// - 命名字符引用按文档类型选择字符引用表: HTML 4.01 及 XHTML 使用 HTML 4.01 表(XHTML 额外支持 &apos;)，HTML5 使用完整的 HTML5 表，XML 1.0 只支持 5 个预定义引用
+// - 数字字符引用(&#NNN; 及 &#xHHH;)解码为文档类型中允许的字符
+// - 引号是否解码由 EntCompat、EntQuotes 决定；不合法或不允许解码的引用原样保留
+// - 与 PHP 一致，字符引用必须以 ';' 结尾
+func EntityDecode(s string, flags Flags) string {
+	if strings.IndexByte(s, '&') < 0 {
+		return s
+	}
+	doctype := flags & docTypeMask
+
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for i := 0; i < len(s); {
+		// 最短的字符引用为 4 字节，e.g. "&lt;"
+		if s[i] != '&' || i+3 >= len(s) {
+			buf.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		var decoded string
+		var end int
+		var ok bool
+		if s[i+1] == '#' {
+			var cp rune
+			cp, end, ok = parseNumericReference(s, i+2)
+			if ok && isAllowedChar(cp, doctype) && !(doctype == EntHTML5 && cp == '\r') {
+				decoded = string(cp)
+			} else {
+				ok = false
+			}
+		} else if end, ok = scanEntityName(s, i+1); ok {
+			decoded, ok = lookupEntity(s[i+1:end], doctype)
+		}
+		if ok && ((decoded == "'" && flags&quoteSingle == 0) || (decoded == `"` && flags&quoteDouble == 0)) {
+			ok = false
+		}
+
+		if !ok {
+			buf.WriteByte('&')
+			i++
+			continue
+		}
+		buf.WriteString(decoded)
+		i = end + 1
+	}
+	return buf.String()
+}
+
+// scanEntityName 扫描从 start 开始的字符引用名称(字母或数字)，返回结尾 ';' 的位置，名称为空或未以 ';' 结尾时返回 false
+func scanEntityName(s string, start int) (end int, ok bool) {
+	end = start
+	for end < len(s) && ascii.IsAlphaNum(s[end]) {
+		end++
+	}
+	return end, end > start && end < len(s) && s[end] == ';'
+}
+
+// parseNumericReference 解析从 start 开始的数字字符引用(不含 "&#")，返回码点及结尾 ';' 的位置
+// 与 PHP 一致: 'x' 或 'X' 开头为十六进制，数字后必须是 ';'，码点不能超过 U+10FFFF
+func parseNumericReference(s string, start int) (cp rune, end int, ok bool) {
+	base := 10
+	i := start
+	if i < len(s) && (s[i] == 'x' || s[i] == 'X') {
+		base = 16
+		i++
+	}
+
+	var value int64
+	digits := 0
+	for ; i < len(s); i++ {
+		d, ok := ascii.ParseDigit(s[i], base)
+		if !ok {
+			break
+		}
+		// 超过 U+10FFFF 后不再累加，避免溢出
+		if value <= utf8.MaxRune {
+			value = value*int64(base) + int64(d)
+		}
+		digits++
+	}
+	if digits == 0 || i >= len(s) || s[i] != ';' || value > utf8.MaxRune {
+		return 0, i, false
+	}
+	return rune(value), i, true
+}
+
+// isNonCharacter 判断是否为 U+FDD0~U+FDEF 或各平面末尾两个码点等非字符
+func isNonCharacter(cp rune) bool {
+	return cp&0xFFFF >= 0xFFFE || (cp >= 0xFDD0 && cp <= 0xFDEF)
+}
+
+// isAllowedChar 判断字符是否允许出现在文档类型中，对应 PHP 源码中的 unicode_cp_is_allowed()
+func isAllowedChar(cp rune, doctype Flags) bool {
+	switch doctype {
+	case EntHTML401:
+		return (cp >= 0x20 && cp <= 0x7E) ||
+			cp == '\n' || cp == '\t' || cp == '\r' ||
+			(cp >= 0xA0 && cp <= 0xD7FF) ||
+			(cp >= 0xE000 && cp <= utf8.MaxRune && !isNonCharacter(cp))
+	case EntHTML5:
+		return (cp >= 0x20 && cp <= 0x7E) ||
+			(cp >= 0x09 && cp <= 0x0D && cp != 0x0B) ||
+			(cp >= 0xA0 && cp <= 0xD7FF) ||
+			(cp >= 0xE000 && cp <= utf8.MaxRune && !isNonCharacter(cp))
+	default: // EntXHTML、EntXML1
+		return (cp >= 0x20 && cp <= 0xD7FF) ||
+			cp == '\n' || cp == '\t' || cp == '\r' ||
+			(cp >= 0xE000 && cp <= utf8.MaxRune && cp != 0xFFFE && cp != 0xFFFF)
+	}
+}
+
+// isAllowedNumericReference 判断数字字符引用在文档类型中是否合法，比 isAllowedChar 宽松
+// 对应 PHP 源码中的 numeric_entity_is_allowed()
+func isAllowedNumericReference(cp rune, doctype Flags) bool {
+	switch doctype {
+	case EntHTML401:
+		return cp <= utf8.MaxRune
+	case EntHTML5:
+		return (cp >= 0x20 && cp <= 0x7E) ||
+			(cp >= 0x09 && cp <= 0x0C && cp != 0x0B) ||
+			(cp >= 0xA0 && cp <= 0xD7FF) ||
+			(cp >= 0xE000 && cp <= utf8.MaxRune && !isNonCharacter(cp))
+	default:
+		return isAllowedChar(cp, doctype)
+	}
+}
+
+// utf8Lead、utf8Trail 判断 UTF-8 首字节及后续字节
+func utf8Lead(c byte) bool  { return c < 0x80 || (c >= 0xC2 && c <= 0xF4) }
+func utf8Trail(c byte) bool { return c >= 0x80 && c <= 0xBF }
+
+// nextChar 解码 s[pos:] 开头的 UTF-8 字符，与 PHP 源码中 get_next_char() 的 UTF-8 分支一致
+// 无效序列时返回 false，size 为按 UTR #36 策略应跳过的字节数(不跳过可作为合法序列开头的字节)
+func nextChar(s string, pos int) (cp rune, size int, ok bool) {
+	c := s[pos]
+	avail := len(s) - pos
+	// trailFail 返回前 n 个后续字节中首个不是后续字节的位置对应的跳过字节数
+	trailFail := func(n int) int {
+		for k := 1; k <= n; k++ {
+			if avail <= k || utf8Lead(s[pos+k]) {
+				return k
+			}
+		}
+		return n + 1
+	}
+
+	switch {
+	case c < 0x80:
+		return rune(c), 1, true
+	case c < 0xC2:
+		return 0, 1, false
+	case c < 0xE0:
+		if avail < 2 {
+			return 0, 1, false
+		}
+		if !utf8Trail(s[pos+1]) {
+			if utf8Lead(s[pos+1]) {
+				return 0, 1, false
+			}
+			return 0, 2, false
+		}
+		return rune(c&0x1F)<<6 | rune(s[pos+1]&0x3F), 2, true
+	case c < 0xF0:
+		if avail < 3 || !utf8Trail(s[pos+1]) || !utf8Trail(s[pos+2]) {
+			return 0, trailFail(2), false
+		}
+		cp = rune(c&0x0F)<<12 | rune(s[pos+1]&0x3F)<<6 | rune(s[pos+2]&0x3F)
+		if cp < 0x800 || (cp >= 0xD800 && cp <= 0xDFFF) {
+			return 0, 3, false
+		}
+		return cp, 3, true
+	case c < 0xF5:
+		if avail < 4 || !utf8Trail(s[pos+1]) || !utf8Trail(s[pos+2]) || !utf8Trail(s[pos+3]) {
+			return 0, trailFail(3), false
+		}
+		cp = rune(c&0x07)<<18 | rune(s[pos+1]&0x3F)<<12 | rune(s[pos+2]&0x3F)<<6 | rune(s[pos+3]&0x3F)
+		if cp < 0x10000 || cp > utf8.MaxRune {
+			return 0, 4, false
+		}
+		return cp, 4, true
+	default:
+		return 0, 1, false
+	}
+}
@@ -0,0 +1,88 @@
+package xhtml
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var flagNames = map[string]Flags{
+	"ENT_NOQUOTES":   EntNoQuotes,
+	"ENT_COMPAT":     EntCompat,
+	"ENT_QUOTES":     EntQuotes,
+	"ENT_IGNORE":     EntIgnore,
+	"ENT_SUBSTITUTE": EntSubstitute,
+	"ENT_DISALLOWED": EntDisallowed,
+	"ENT_HTML401":    EntHTML401,
+	"ENT_XML1":       EntXML1,
+	"ENT_XHTML":      EntXHTML,
+	"ENT_HTML5":      EntHTML5,
+}
+
+// parseFlags 解析 "ENT_QUOTES|ENT_HTML5|!double_encode" 形式的选项
+func parseFlags(t *testing.T, s string) (flags Flags, doubleEncode bool) {
+	doubleEncode = true
+	for _, name := range strings.Split(s, "|") {
+		if name == "!double_encode" {
+			doubleEncode = false
+			continue
+		}
+		f, ok := flagNames[name]
+		if !ok {
+			t.Fatalf("unknown flag %q", name)
+		}
+		flags |= f
+	}
+	return flags, doubleEncode
+}
+
+func TestGolden(t *testing.T) {
+	f, err := os.Open("testdata/golden.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			t.Fatalf("line %d: expected 4 fields, got %d", lineNo, len(fields))
+		}
+		input, err1 := strconv.Unquote(fields[2])
+		want, err2 := strconv.Unquote(fields[3])
+		if err1 != nil || err2 != nil {
+			t.Fatalf("line %d: invalid string literal", lineNo)
+		}
+
+		var got string
+		switch fields[0] {
+		case "htmlspecialchars":
+			flags, doubleEncode := parseFlags(t, fields[1])
+			got = SpecialChars(input, flags, doubleEncode)
+		case "html_entity_decode":
+			flags, _ := parseFlags(t, fields[1])
+			got = EntityDecode(input, flags)
+		case "strip_tags":
+			allowed, err := strconv.Unquote(fields[1])
+			if err != nil {
+				t.Fatalf("line %d: invalid allowed tags", lineNo)
+			}
+			got = StripTags(input, allowed)
+		default:
+			t.Fatalf("line %d: unknown function %q", lineNo, fields[0])
+		}
+		if got != want {
+			t.Errorf("line %d: %s(%s, %s) = %q, want %q", lineNo, fields[0], fields[2], fields[1], got, want)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
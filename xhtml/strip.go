@@ -0,0 +1,281 @@
+package xhtml
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"github.com/heyuuu/gophp-utils/xstrings"
+	"strings"
+)
+
+// StripTags 去除 HTML 及 PHP 标签，对应 PHP 函数 strip_tags()，与 PHP 源码中 php_strip_tags_ex() 的状态机逐字节一致
+// - allowed 为保留的标签列表，格式同 PHP 的字符串参数，e.g. "<a><br>"，不区分大小写；PHP 数组参数 ["a", "br"] 等价于 "<a><br>"
+// - 注释 <!-- -->、<!DOCTYPE>、PHP 代码 <?php ?> 总是被去除
+// - 与 PHP 一致，NUL 字节总是被去除；'<' 后紧跟空白字符时不视为标签
+// - 保留的标签中，引号内的 '<'、'>' 会被去除
+func StripTags(s string, allowed string) string {
+	st := stripState{s: s}
+	if allowed != "" {
+		st.allowed = xstrings.ToLower(allowed)
+		st.keep = true
+	}
+	return st.run()
+}
+
+type stripState struct {
+	s       string
+	allowed string
+	keep    bool // 是否需要记录标签内容以判断是否保留
+
+	out   strings.Builder
+	tag   []byte // 当前标签的内容
+	state int    // 0: 文本; 1: HTML 标签; 2: PHP 代码; 3: <! 开头的标签; 4: 注释
+	depth int    // 标签内嵌套的 '<' 层数
+	inQ   byte   // 当前所在引号，0 表示不在引号内
+	lc    byte   // 上一个有意义的字符
+	br    int    // PHP 代码中的括号层数
+	isXML bool   // 是否为 <?xml 标签
+}
+
+// at 返回 s[i]，越界时返回 0，对应 C 字符串末尾的 '\0'
+func (st *stripState) at(i int) byte {
+	if i < 0 || i >= len(st.s) {
+		return 0
+	}
+	return st.s[i]
+}
+
+// atFold 判断 s[i] 是否为字母 c(不区分大小写)
+func (st *stripState) atFold(i int, c byte) bool {
+	return ascii.ToLower(st.at(i)) == c
+}
+
+func (st *stripState) addTag(c byte) {
+	if st.keep {
+		st.tag = append(st.tag, c)
+	}
+}
+
+func (st *stripState) run() string {
+	st.out.Grow(len(st.s))
+	for p := 0; p < len(st.s); p++ {
+		c := st.s[p]
+		switch st.state {
+		case 0:
+			st.text(p, c)
+		case 1:
+			st.htmlTag(p, c)
+		case 2:
+			st.phpCode(p, c)
+		case 3:
+			st.bangTag(p, c)
+		case 4:
+			if c == '>' && st.inQ == 0 && st.at(p-1) == '-' && st.at(p-2) == '-' {
+				st.reset()
+			}
+		}
+	}
+	return st.out.String()
+}
+
+// reset 标签结束，回到文本状态
+func (st *stripState) reset() {
+	st.inQ, st.state = 0, 0
+	st.tag = st.tag[:0]
+}
+
+func (st *stripState) text(p int, c byte) {
+	switch c {
+	case 0:
+	case '<':
+		if st.inQ != 0 {
+			return
+		}
+		if ascii.IsSpace(st.at(p + 1)) {
+			st.out.WriteByte(c)
+			return
+		}
+		st.lc = '<'
+		st.state = 1
+		st.addTag('<')
+	case '>':
+		if st.depth > 0 {
+			st.depth--
+			return
+		}
+		if st.inQ != 0 {
+			return
+		}
+		st.out.WriteByte(c)
+	default:
+		st.out.WriteByte(c)
+	}
+}
+
+func (st *stripState) htmlTag(p int, c byte) {
+	switch c {
+	case 0:
+	case '<':
+		if st.inQ != 0 {
+			return
+		}
+		if ascii.IsSpace(st.at(p + 1)) {
+			st.addTag(c)
+			return
+		}
+		st.depth++
+	case '>':
+		if st.depth > 0 {
+			st.depth--
+			return
+		}
+		if st.inQ != 0 {
+			return
+		}
+		st.lc = '>'
+		if st.isXML && st.at(p-1) == '-' {
+			return
+		}
+		st.inQ, st.state, st.isXML = 0, 0, false
+		if st.keep {
+			st.tag = append(st.tag, '>')
+			if tagAllowed(st.tag, st.allowed) {
+				st.out.Write(st.tag)
+			}
+			st.tag = st.tag[:0]
+		}
+	case '"', '\'':
+		if st.inQ == 0 {
+			st.inQ = c
+		} else if st.inQ == c {
+			st.inQ = 0
+		}
+		st.addTag(c)
+	case '!':
+		// <! 开头的标签，e.g. 注释、<!DOCTYPE>
+		if st.at(p-1) == '<' {
+			st.state = 3
+			st.lc = c
+			return
+		}
+		st.addTag(c)
+	case '?':
+		// PHP 代码
+		if st.at(p-1) == '<' {
+			st.br = 0
+			st.state = 2
+			return
+		}
+		st.addTag(c)
+	default:
+		st.addTag(c)
+	}
+}
+
+func (st *stripState) phpCode(p int, c byte) {
+	switch c {
+	case '(':
+		if st.lc != '"' && st.lc != '\'' {
+			st.lc = '('
+			st.br++
+		}
+	case ')':
+		if st.lc != '"' && st.lc != '\'' {
+			st.lc = ')'
+			st.br--
+		}
+	case '>':
+		if st.depth > 0 {
+			st.depth--
+			return
+		}
+		if st.inQ != 0 {
+			return
+		}
+		if st.br == 0 && st.lc != '"' && st.at(p-1) == '?' {
+			st.reset()
+		}
+	case '"', '\'':
+		if p >= 1 && st.at(p-1) != '\\' {
+			if st.lc == c {
+				st.lc = 0
+			} else if st.lc != '\\' {
+				st.lc = c
+			}
+			if st.inQ == 0 {
+				st.inQ = c
+			} else if st.inQ == c {
+				st.inQ = 0
+			}
+		}
+	case 'l', 'L':
+		// <?xml 不是 PHP 代码，按 HTML 标签处理
+		if p > 4 && st.atFold(p-1, 'm') && st.atFold(p-2, 'x') && st.at(p-3) == '?' && st.at(p-4) == '<' {
+			st.state = 1
+			st.isXML = true
+		}
+	}
+}
+
+func (st *stripState) bangTag(p int, c byte) {
+	switch c {
+	case '>':
+		if st.depth > 0 {
+			st.depth--
+			return
+		}
+		if st.inQ != 0 {
+			return
+		}
+		st.reset()
+	case '"', '\'':
+		if st.at(p-1) != '\\' {
+			if st.inQ == 0 {
+				st.inQ = c
+			} else if st.inQ == c {
+				st.inQ = 0
+			}
+		}
+	case '-':
+		if p >= 2 && st.at(p-1) == '-' && st.at(p-2) == '!' {
+			st.state = 4
+		}
+	case 'e', 'E':
+		// <!DOCTYPE 按 HTML 标签处理
+		if p > 6 && st.atFold(p-1, 'p') && st.atFold(p-2, 'y') && st.atFold(p-3, 't') &&
+			st.atFold(p-4, 'c') && st.atFold(p-5, 'o') && st.atFold(p-6, 'd') {
+			st.state = 1
+		}
+	}
+}
+
+// tagAllowed 判断标签是否在允许列表中，对应 PHP 源码中的 php_tag_find()
+// 标签规范化为 "<name>": 转为小写，去除属性及空白，去除紧跟 '<' 或紧邻 '>' 的 '/'，e.g. "</B>" => "<b>"、"<br/>" => "<br>"
+func tagAllowed(tag []byte, allowed string) bool {
+	norm := make([]byte, 0, len(tag)+1)
+	started := false
+loop:
+	for i := 0; i < len(tag); i++ {
+		c := ascii.ToLower(tag[i])
+		switch {
+		case c == '<':
+			norm = append(norm, c)
+		case c == '>':
+			break loop
+		case !ascii.IsSpace(c):
+			started = true
+			prev, next := byte(0), byte(0)
+			if i > 0 {
+				prev = tag[i-1]
+			}
+			if i+1 < len(tag) {
+				next = tag[i+1]
+			}
+			if c != '/' || (prev != '<' && next != '>') {
+				norm = append(norm, c)
+			}
+		case started:
+			break loop
+		}
+	}
+	norm = append(norm, '>')
+	return strings.Contains(allowed, string(norm))
+}
@@ -0,0 +1,185 @@
+package xmath
+
+import (
+	"math"
+	"strconv"
+)
+
+// RoundingMode 舍入模式，取值与 PHP 源码中的 PHP_ROUND_* 常量一致
+type RoundingMode int
+
+const (
+	RoundHalfUp       RoundingMode = iota + 1 // 四舍五入，中间值远离 0，对应 PHP_ROUND_HALF_UP
+	RoundHalfDown                             // 中间值趋向 0，对应 PHP_ROUND_HALF_DOWN
+	RoundHalfEven                             // 中间值取偶数，对应 PHP_ROUND_HALF_EVEN
+	RoundHalfOdd                              // 中间值取奇数，对应 PHP_ROUND_HALF_ODD
+	RoundCeiling                              // 向正无穷舍入，对应 PHP 8.4 的 RoundingMode::PositiveInfinity
+	RoundFloor                                // 向负无穷舍入，对应 PHP 8.4 的 RoundingMode::NegativeInfinity
+	RoundTowardsZero                          // 向 0 舍入，对应 PHP 8.4 的 RoundingMode::TowardsZero
+	RoundAwayFromZero                         // 远离 0 舍入，对应 PHP 8.4 的 RoundingMode::AwayFromZero
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundHalfDown:
+		return "HalfDown"
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundHalfOdd:
+		return "HalfOdd"
+	case RoundCeiling:
+		return "Ceiling"
+	case RoundFloor:
+		return "Floor"
+	case RoundTowardsZero:
+		return "TowardsZero"
+	case RoundAwayFromZero:
+		return "AwayFromZero"
+	default:
+		return "RoundingMode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// IsValid 判断是否为合法的舍入模式
+func (m RoundingMode) IsValid() bool {
+	return m >= RoundHalfUp && m <= RoundAwayFromZero
+}
+
+// pow10Table 10 的 0~22 次方，均可被 float64 精确表示
+var pow10Table = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20,
+	1e21, 1e22,
+}
+
+// pow10 返回 10 的 n 次方，对应 PHP 源码中的 php_intpow10()
+func pow10(n int) float64 {
+	if n < 0 || n > 22 {
+		return math.Pow(10, float64(n))
+	}
+	return pow10Table[n]
+}
+
+// Round 将 value 按指定模式舍入到 places 位小数，places 为负数时舍入到整数位，对应 PHP 8.4 函数 round()
+// - 按十进制意义判断中间值，e.g. Round(0.285, 2, RoundHalfUp) == 0.29 (0.285 的二进制值略小于 0.285)
+// - 整数部分超出 1e16 时精度已不足，原样返回
+// - ±Inf、NaN、±0 原样返回
+// mode 不合法时 panic
+func Round(value float64, places int, mode RoundingMode) float64 {
+	if !mode.IsValid() {
+		panic("xmath: invalid rounding mode " + mode.String())
+	}
+	if math.IsInf(value, 0) || math.IsNaN(value) || value == 0 {
+		return value
+	}
+
+	places = min(max(places, math.MinInt32+1), math.MaxInt32)
+	exponent := pow10(absInt(places))
+
+	// 取整数部分；value 恰好可以表示为下一个整数时以其为准，修正浮点误差
+	// e.g. 0.285 * 100 = 28.499999999999996
+	var integral, next float64
+	if value >= 0 {
+		integral = math.Floor(scale(value, exponent, places))
+		next = integral + 1
+	} else {
+		integral = math.Ceil(scale(value, exponent, places))
+		next = integral - 1
+	}
+	if unscale(next, exponent, places) == value {
+		integral = next
+	}
+
+	// 超出精度范围，舍入没有意义
+	if math.Abs(integral) >= 1e16 {
+		return value
+	}
+
+	integral = roundIntegral(integral, value, exponent, places, mode)
+
+	if absInt(places) < 23 {
+		return unscale(integral, exponent, places)
+	}
+	// 超出常量表范围时除法不精确，转为字符串后重新解析
+	result, err := strconv.ParseFloat(strconv.FormatFloat(integral, 'f', -1, 64)+"e"+strconv.Itoa(-places), 64)
+	if err != nil || math.IsInf(result, 0) || math.IsNaN(result) {
+		return value
+	}
+	return result
+}
+
+// roundIntegral 根据舍入模式决定是否对整数部分进位，对应 PHP 源码中的 php_round_helper()
+func roundIntegral(integral, value, exponent float64, places int, mode RoundingMode) float64 {
+	valueAbs := math.Abs(value)
+	away := integral + math.Copysign(1, integral)
+
+	switch mode {
+	case RoundHalfUp:
+		if valueAbs >= halfEdge(integral, exponent, places) {
+			return away
+		}
+	case RoundHalfDown:
+		if valueAbs > halfEdge(integral, exponent, places) {
+			return away
+		}
+	case RoundHalfEven, RoundHalfOdd:
+		edge := halfEdge(integral, exponent, places)
+		if valueAbs > edge {
+			return away
+		}
+		if valueAbs == edge {
+			even := math.Mod(integral, 2) == 0
+			if even != (mode == RoundHalfEven) {
+				return away
+			}
+		}
+	case RoundCeiling:
+		if value > 0 && valueAbs > zeroEdge(integral, exponent, places) {
+			return integral + 1
+		}
+	case RoundFloor:
+		if value < 0 && valueAbs > zeroEdge(integral, exponent, places) {
+			return integral - 1
+		}
+	case RoundAwayFromZero:
+		if valueAbs > zeroEdge(integral, exponent, places) {
+			return away
+		}
+	}
+	return integral
+}
+
+// halfEdge 返回整数部分与下一整数之间的中间值(还原后的绝对值)
+func halfEdge(integral, exponent float64, places int) float64 {
+	return math.Abs(unscale(integral+math.Copysign(0.5, integral), exponent, places))
+}
+
+// zeroEdge 返回整数部分本身(还原后的绝对值)
+func zeroEdge(integral, exponent float64, places int) float64 {
+	return math.Abs(unscale(integral, exponent, places))
+}
+
+// scale 按 places 放大(或缩小) v
+func scale(v, exponent float64, places int) float64 {
+	if places > 0 {
+		return v * exponent
+	}
+	return v / exponent
+}
+
+// unscale 将放大(或缩小)后的值还原
+func unscale(v, exponent float64, places int) float64 {
+	if places > 0 {
+		return v / exponent
+	}
+	return v * exponent
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
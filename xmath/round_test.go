@@ -0,0 +1,102 @@
+package xmath
+
+import (
+	"math"
+	"testing"
+)
+
+// 以下期望值均为 PHP 8.4 的输出
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value  float64
+		places int
+		mode   RoundingMode
+		want   float64
+	}{
+		{1.4, 0, RoundHalfUp, 1},
+		{1.5, 0, RoundHalfUp, 2},
+		{-1.5, 0, RoundHalfUp, -2},
+		{2.5, 0, RoundHalfUp, 3},
+		{0.285, 2, RoundHalfUp, 0.29},
+		{-0.285, 2, RoundHalfUp, -0.29},
+		{1.005, 2, RoundHalfUp, 1.01},
+		{1.45, 1, RoundHalfUp, 1.5},
+		{0.49999999999999994, 0, RoundHalfUp, 0},
+		{1234567.891, -3, RoundHalfUp, 1235000},
+		{1250, -2, RoundHalfUp, 1300},
+		{5.0e-324, 2, RoundHalfUp, 0},
+
+		{1.5, 0, RoundHalfDown, 1},
+		{-1.5, 0, RoundHalfDown, -1},
+		{1.51, 0, RoundHalfDown, 2},
+		{0.285, 2, RoundHalfDown, 0.28},
+		{1250, -2, RoundHalfDown, 1200},
+
+		{1.5, 0, RoundHalfEven, 2},
+		{2.5, 0, RoundHalfEven, 2},
+		{-2.5, 0, RoundHalfEven, -2},
+		{-3.5, 0, RoundHalfEven, -4},
+		{0.285, 2, RoundHalfEven, 0.28},
+		{0.295, 2, RoundHalfEven, 0.3},
+		{2.51, 0, RoundHalfEven, 3},
+
+		{1.5, 0, RoundHalfOdd, 1},
+		{2.5, 0, RoundHalfOdd, 3},
+		{-2.5, 0, RoundHalfOdd, -3},
+		{0.285, 2, RoundHalfOdd, 0.29},
+
+		{1.1, 0, RoundCeiling, 2},
+		{-1.9, 0, RoundCeiling, -1},
+		{1.0, 0, RoundCeiling, 1},
+		{0.281, 2, RoundCeiling, 0.29},
+		{0.28, 2, RoundCeiling, 0.28},
+		{1201, -2, RoundCeiling, 1300},
+
+		{1.9, 0, RoundFloor, 1},
+		{-1.1, 0, RoundFloor, -2},
+		{-1.0, 0, RoundFloor, -1},
+		{-0.281, 2, RoundFloor, -0.29},
+
+		{1.9, 0, RoundTowardsZero, 1},
+		{-1.9, 0, RoundTowardsZero, -1},
+		{0.289, 2, RoundTowardsZero, 0.28},
+
+		{1.1, 0, RoundAwayFromZero, 2},
+		{-1.1, 0, RoundAwayFromZero, -2},
+		{1.0, 0, RoundAwayFromZero, 1},
+		{-0.3, 0, RoundAwayFromZero, -1},
+		{0.281, 2, RoundAwayFromZero, 0.29},
+
+		{1e16 + 2, 0, RoundHalfUp, 1e16 + 2},
+		{1.23456789e-30, 35, RoundHalfUp, 1.23457e-30},
+		{123.456, math.MaxInt, RoundHalfUp, 123.456},
+		{123.456, math.MinInt, RoundHalfUp, 0},
+	}
+	for _, tt := range tests {
+		if got := Round(tt.value, tt.places, tt.mode); got != tt.want {
+			t.Errorf("Round(%v, %d, %v) = %v, want %v", tt.value, tt.places, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestRoundSpecial(t *testing.T) {
+	for mode := RoundHalfUp; mode <= RoundAwayFromZero; mode++ {
+		if got := Round(math.Inf(1), 2, mode); !math.IsInf(got, 1) {
+			t.Errorf("Round(+Inf, 2, %v) = %v", mode, got)
+		}
+		if got := Round(math.NaN(), 2, mode); !math.IsNaN(got) {
+			t.Errorf("Round(NaN, 2, %v) = %v", mode, got)
+		}
+		if got := Round(math.Copysign(0, -1), 2, mode); got != 0 || !math.Signbit(got) {
+			t.Errorf("Round(-0, 2, %v) = %v", mode, got)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Round() with invalid mode should panic")
+		}
+	}()
+	Round(1, 0, RoundingMode(0))
+}
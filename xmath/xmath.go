@@ -0,0 +1,164 @@
+package xmath
+
+// PHP 整数运算相关函数
+// PHP 中 int 运算溢出时会提升为 float 继续计算，除法及取模的除数为 0 时抛出异常，本包按 PHP 源码逻辑实现
+
+import (
+	"errors"
+	"github.com/heyuuu/gophp-utils/numeric"
+	"math"
+	"math/bits"
+)
+
+var (
+	ErrDivisionByZero = errors.New("xmath: division by zero")
+	ErrModuloByZero   = errors.New("xmath: modulo by zero")
+	ErrIntdivMinInt   = errors.New("xmath: division of PHP_INT_MIN by -1 is not an integer")
+)
+
+// AddOverflow 计算 a + b，返回按补码回绕的结果及是否溢出
+func AddOverflow(a, b int64) (int64, bool) {
+	sum := a + b
+	// 两数同号且结果符号不同时溢出
+	return sum, (a^sum)&(b^sum) < 0
+}
+
+// SubOverflow 计算 a - b，返回按补码回绕的结果及是否溢出
+func SubOverflow(a, b int64) (int64, bool) {
+	diff := a - b
+	// 两数异号且结果符号与 a 不同时溢出
+	return diff, (a^b)&(a^diff) < 0
+}
+
+// MulOverflow 计算 a * b，返回按补码回绕的结果及是否溢出
+func MulOverflow(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(uint64(abs(a)), uint64(abs(b)))
+	product := a * b
+	if hi != 0 {
+		return product, true
+	}
+	// 无符号结果需在有符号范围内，负数允许等于 |MinInt64|
+	if (a < 0) != (b < 0) && a != 0 && b != 0 {
+		return product, lo > 1<<63
+	}
+	return product, lo >= 1<<63
+}
+
+// abs 返回 a 的绝对值，MinInt64 的结果按无符号数解释时正确
+func abs(a int64) int64 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Add 计算 a + b，对应 PHP 中 int 的 + 运算，溢出时提升为 float
+func Add(a, b int64) numeric.Number {
+	if sum, overflow := AddOverflow(a, b); !overflow {
+		return numeric.IntNumber(sum)
+	}
+	return numeric.FloatNumber(float64(a) + float64(b))
+}
+
+// Sub 计算 a - b，对应 PHP 中 int 的 - 运算，溢出时提升为 float
+func Sub(a, b int64) numeric.Number {
+	if diff, overflow := SubOverflow(a, b); !overflow {
+		return numeric.IntNumber(diff)
+	}
+	return numeric.FloatNumber(float64(a) - float64(b))
+}
+
+// Mul 计算 a * b，对应 PHP 中 int 的 * 运算，溢出时提升为 float
+func Mul(a, b int64) numeric.Number {
+	if product, overflow := MulOverflow(a, b); !overflow {
+		return numeric.IntNumber(product)
+	}
+	return numeric.FloatNumber(float64(a) * float64(b))
+}
+
+// Div 计算 a / b，对应 PHP 中 int 的 / 运算
+// - 能整除时结果为 int，否则为 float，e.g. (6, 3) => 2、(7, 2) => 3.5
+// - MinInt64 / -1 结果为 float
+// - b 为 0 时返回 ErrDivisionByZero
+func Div(a, b int64) (numeric.Number, error) {
+	switch {
+	case b == 0:
+		return numeric.Number{}, ErrDivisionByZero
+	case b == -1 && a == math.MinInt64:
+		return numeric.FloatNumber(float64(a) / -1), nil
+	case a%b == 0:
+		return numeric.IntNumber(a / b), nil
+	default:
+		return numeric.FloatNumber(float64(a) / float64(b)), nil
+	}
+}
+
+// Intdiv 整数除法，结果向 0 取整，对应 PHP 函数 intdiv()
+// - b 为 0 时返回 ErrDivisionByZero
+// - MinInt64 / -1 时返回 ErrIntdivMinInt
+func Intdiv(a, b int64) (int64, error) {
+	switch {
+	case b == 0:
+		return 0, ErrDivisionByZero
+	case b == -1 && a == math.MinInt64:
+		return 0, ErrIntdivMinInt
+	default:
+		return a / b, nil
+	}
+}
+
+// Mod 整数取模，结果符号与 a 相同，对应 PHP 中的 % 运算
+// - b 为 0 时返回 ErrModuloByZero
+// - b 为 -1 时结果总为 0 (包括 MinInt64 % -1)
+func Mod(a, b int64) (int64, error) {
+	switch {
+	case b == 0:
+		return 0, ErrModuloByZero
+	case b == -1:
+		return 0, nil
+	default:
+		return a % b, nil
+	}
+}
+
+// Fmod 浮点数取模，结果符号与 x 相同，对应 PHP 函数 fmod() (即 C 语言 fmod())
+// y 为 0 或 x 为无穷大时返回 NaN
+func Fmod(x, y float64) float64 {
+	return math.Mod(x, y)
+}
+
+// Pow 计算 base 的 exp 次方，对应 PHP 中 int 的 ** 运算及 pow() 函数
+// - exp >= 0 时使用快速幂计算，结果在 int64 范围内时为 int，溢出时提升为 float
+// - exp < 0 时结果为 float
+func Pow(base, exp int64) numeric.Number {
+	if exp < 0 {
+		return numeric.FloatNumber(math.Pow(float64(base), float64(exp)))
+	}
+	if exp == 0 {
+		return numeric.IntNumber(1)
+	}
+	if base == 0 {
+		return numeric.IntNumber(0)
+	}
+
+	// 与 PHP 源码一致: 溢出后剩余部分使用浮点数计算
+	result, l2, i := int64(1), base, exp
+	for i >= 1 {
+		if i%2 != 0 {
+			i--
+			product, overflow := MulOverflow(result, l2)
+			if overflow {
+				return numeric.FloatNumber(float64(result) * float64(l2) * math.Pow(float64(l2), float64(i)))
+			}
+			result = product
+		} else {
+			i /= 2
+			square, overflow := MulOverflow(l2, l2)
+			if overflow {
+				return numeric.FloatNumber(float64(result) * math.Pow(float64(l2)*float64(l2), float64(i)))
+			}
+			l2 = square
+		}
+	}
+	return numeric.IntNumber(result)
+}
@@ -0,0 +1,195 @@
+package xmath
+
+import (
+	"errors"
+	"github.com/heyuuu/gophp-utils/numeric"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// boundaryValues int64 边界附近的测试值
+var boundaryValues = []int64{
+	math.MinInt64, math.MinInt64 + 1, math.MinInt64 + 2,
+	math.MinInt64 / 2, math.MinInt64/2 - 1, math.MinInt64/2 + 1,
+	-3037000500, -3037000499, // ±sqrt(MaxInt64) 附近
+	math.MinInt32 - 1, math.MinInt32, math.MinInt32 + 1,
+	-3, -2, -1, 0, 1, 2, 3,
+	math.MaxInt32 - 1, math.MaxInt32, math.MaxInt32 + 1,
+	3037000499, 3037000500,
+	math.MaxInt64 / 2, math.MaxInt64/2 + 1, math.MaxInt64/2 + 2,
+	math.MaxInt64 - 2, math.MaxInt64 - 1, math.MaxInt64,
+}
+
+// checkOverflow 使用 big.Int 计算期望值并校验
+func checkOverflow(t *testing.T, name string, a, b int64, got int64, gotOverflow bool, op func(z, x, y *big.Int) *big.Int) {
+	t.Helper()
+	want := op(new(big.Int), big.NewInt(a), big.NewInt(b))
+	wantOverflow := !want.IsInt64()
+	// 回绕结果等于精确值的低 64 位
+	wrapped := int64(new(big.Int).And(want, new(big.Int).SetUint64(math.MaxUint64)).Uint64())
+	if got != wrapped || gotOverflow != wantOverflow {
+		t.Errorf("%s(%d, %d) = %d, %v, want %d, %v", name, a, b, got, gotOverflow, wrapped, wantOverflow)
+	}
+}
+
+func TestAddSubMulOverflow(t *testing.T) {
+	for _, a := range boundaryValues {
+		for _, b := range boundaryValues {
+			sum, overflow := AddOverflow(a, b)
+			checkOverflow(t, "AddOverflow", a, b, sum, overflow, (*big.Int).Add)
+			diff, overflow := SubOverflow(a, b)
+			checkOverflow(t, "SubOverflow", a, b, diff, overflow, (*big.Int).Sub)
+			product, overflow := MulOverflow(a, b)
+			checkOverflow(t, "MulOverflow", a, b, product, overflow, (*big.Int).Mul)
+		}
+	}
+}
+
+func TestAddSubMul(t *testing.T) {
+	tests := []struct {
+		got  numeric.Number
+		want numeric.Number
+	}{
+		{Add(1, 2), numeric.IntNumber(3)},
+		{Add(math.MaxInt64, 1), numeric.FloatNumber(9223372036854775808)},
+		{Add(math.MinInt64, -1), numeric.FloatNumber(-9223372036854775809)},
+		{Add(math.MaxInt64, math.MinInt64), numeric.IntNumber(-1)},
+		{Sub(math.MinInt64, 1), numeric.FloatNumber(-9223372036854775809)},
+		{Sub(0, math.MinInt64), numeric.FloatNumber(9223372036854775808)},
+		{Sub(-1, math.MinInt64), numeric.IntNumber(math.MaxInt64)},
+		{Mul(math.MaxInt64, 2), numeric.FloatNumber(18446744073709551614)},
+		{Mul(math.MinInt64, -1), numeric.FloatNumber(9223372036854775808)},
+		{Mul(math.MinInt64, 1), numeric.IntNumber(math.MinInt64)},
+		{Mul(-4611686018427387904, 2), numeric.IntNumber(math.MinInt64)},
+		{Mul(4611686018427387904, 2), numeric.FloatNumber(9223372036854775808)},
+		{Mul(3037000499, 3037000499), numeric.IntNumber(9223372030926249001)},
+		{Mul(3037000500, 3037000500), numeric.FloatNumber(9223372037000250000)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %+v, want %+v", tt.got, tt.want)
+		}
+	}
+}
+
+func TestDiv(t *testing.T) {
+	tests := []struct {
+		a, b    int64
+		want    numeric.Number
+		wantErr error
+	}{
+		{6, 3, numeric.IntNumber(2), nil},
+		{7, 2, numeric.FloatNumber(3.5), nil},
+		{-7, 2, numeric.FloatNumber(-3.5), nil},
+		{1, 3, numeric.FloatNumber(1.0 / 3), nil},
+		{1, 0, numeric.Number{}, ErrDivisionByZero},
+		{math.MinInt64, -1, numeric.FloatNumber(9223372036854775808), nil},
+		{math.MinInt64, 1, numeric.IntNumber(math.MinInt64), nil},
+		{math.MaxInt64, -1, numeric.IntNumber(-math.MaxInt64), nil},
+		{math.MaxInt64, 2, numeric.FloatNumber(4611686018427387904), nil},
+	}
+	for _, tt := range tests {
+		got, err := Div(tt.a, tt.b)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("Div(%d, %d) = %+v, %v, want %+v, %v", tt.a, tt.b, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestIntdivMod(t *testing.T) {
+	tests := []struct {
+		a, b       int64
+		wantDiv    int64
+		wantDivErr error
+		wantMod    int64
+		wantModErr error
+	}{
+		{7, 2, 3, nil, 1, nil},
+		{-7, 2, -3, nil, -1, nil},
+		{7, -2, -3, nil, 1, nil},
+		{-7, -2, 3, nil, -1, nil},
+		{1, 0, 0, ErrDivisionByZero, 0, ErrModuloByZero},
+		{math.MinInt64, -1, 0, ErrIntdivMinInt, 0, nil},
+		{math.MinInt64, 1, math.MinInt64, nil, 0, nil},
+		{math.MinInt64, math.MaxInt64, -1, nil, -1, nil},
+		{math.MaxInt64, math.MinInt64, 0, nil, math.MaxInt64, nil},
+		{math.MaxInt64, -1, -math.MaxInt64, nil, 0, nil},
+	}
+	for _, tt := range tests {
+		gotDiv, err := Intdiv(tt.a, tt.b)
+		if gotDiv != tt.wantDiv || !errors.Is(err, tt.wantDivErr) {
+			t.Errorf("Intdiv(%d, %d) = %d, %v, want %d, %v", tt.a, tt.b, gotDiv, err, tt.wantDiv, tt.wantDivErr)
+		}
+		gotMod, err := Mod(tt.a, tt.b)
+		if gotMod != tt.wantMod || !errors.Is(err, tt.wantModErr) {
+			t.Errorf("Mod(%d, %d) = %d, %v, want %d, %v", tt.a, tt.b, gotMod, err, tt.wantMod, tt.wantModErr)
+		}
+	}
+}
+
+func TestFmod(t *testing.T) {
+	tests := []struct {
+		x, y float64
+		want float64
+	}{
+		{5.7, 1.3, 0.5},
+		{-5.7, 1.3, -0.5},
+		{5.7, -1.3, 0.5},
+		{10, 3, 1},
+		{1e20, 3, 1},
+	}
+	for _, tt := range tests {
+		if got := Fmod(tt.x, tt.y); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("Fmod(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+	if got := Fmod(1, 0); !math.IsNaN(got) {
+		t.Errorf("Fmod(1, 0) = %v, want NaN", got)
+	}
+	if got := Fmod(math.Inf(1), 1); !math.IsNaN(got) {
+		t.Errorf("Fmod(Inf, 1) = %v, want NaN", got)
+	}
+}
+
+func TestPow(t *testing.T) {
+	bases := []int64{math.MinInt64, -3037000500, -3037000499, -65536, -10, -3, -2, -1, 0, 1, 2, 3, 10, 65536, 3037000499, 3037000500, math.MaxInt64}
+	for _, base := range bases {
+		for exp := int64(0); exp <= 70; exp++ {
+			got := Pow(base, exp)
+			want := new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil)
+			if want.IsInt64() {
+				if got != numeric.IntNumber(want.Int64()) {
+					t.Errorf("Pow(%d, %d) = %+v, want int %v", base, exp, got, want)
+				}
+				continue
+			}
+			// 溢出时提升为 float，允许少量精度误差
+			wantFloat, _ := new(big.Float).SetInt(want).Float64()
+			if !got.IsFloat || math.Abs(got.Float-wantFloat) > math.Abs(wantFloat)*1e-14 {
+				t.Errorf("Pow(%d, %d) = %+v, want float %v", base, exp, got, wantFloat)
+			}
+		}
+	}
+
+	tests := []struct {
+		base, exp int64
+		want      numeric.Number
+	}{
+		{2, 62, numeric.IntNumber(4611686018427387904)},
+		{2, 63, numeric.FloatNumber(9223372036854775808)},
+		{-2, 63, numeric.IntNumber(math.MinInt64)},
+		{-2, 64, numeric.FloatNumber(18446744073709551616)},
+		{10, 18, numeric.IntNumber(1e18)},
+		{10, 19, numeric.FloatNumber(1e19)},
+		{2, -1, numeric.FloatNumber(0.5)},
+		{-2, -2, numeric.FloatNumber(0.25)},
+		{0, -1, numeric.FloatNumber(math.Inf(1))},
+		{0, 0, numeric.IntNumber(1)},
+	}
+	for _, tt := range tests {
+		if got := Pow(tt.base, tt.exp); got != tt.want {
+			t.Errorf("Pow(%d, %d) = %+v, want %+v", tt.base, tt.exp, got, tt.want)
+		}
+	}
+}
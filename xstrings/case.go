@@ -6,41 +6,12 @@ package xstrings
 // - 分词时将非 ASCII 字符作为单独类型，不同 ASCII 间不做区分。e.g. "用户のID" 会分词为 "用户の" + "ID"
 
 import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"github.com/heyuuu/gophp-utils/internal/asciicase"
+	"github.com/heyuuu/gophp-utils/internal/swar"
 	"unsafe"
 )
 
-var (
-	byteIsLower [256]bool
-	byteIsUpper [256]bool
-	byteIsAlpha [256]bool
-	byteToLower [256]byte
-	byteToUpper [256]byte
-)
-
-func init() {
-	// init byteIsLower / byteIsUpper / byteIsAlpha
-	for c := byte('a'); c <= byte('z'); c++ {
-		byteIsLower[c] = true
-		byteIsAlpha[c] = true
-	}
-	for c := byte('A'); c <= byte('Z'); c++ {
-		byteIsUpper[c] = true
-		byteIsAlpha[c] = true
-	}
-
-	// init byteToLower / byteToUpper
-	for i := 0; i <= 255; i++ {
-		byteToLower[i] = byte(i)
-		byteToUpper[i] = byte(i)
-	}
-	for c := byte('a'); c <= byte('z'); c++ {
-		byteToUpper[c] = c - 'a' + 'A'
-	}
-	for c := byte('A'); c <= byte('Z'); c++ {
-		byteToLower[c] = c - 'A' + 'a'
-	}
-}
-
 // unsafeBytesToString 字符切片直接转string，要求调用方保证字符切片不再修改
 func unsafeBytesToString(s []byte) string {
 	return unsafe.String(unsafe.SliceData(s), len(s))
@@ -49,28 +20,44 @@ func unsafeBytesToString(s []byte) string {
 // ToUpper 字符串转小写
 // 与标准库 strings.ToUpper() 的区别是，它不处理除英文字母外的其他unicode字母
 func ToUpper(s string) string {
-	var buf []byte
-	for i, c := range []byte(s) {
-		rc := byteToUpper[c]
-		if rc != c {
-			// 懒初始化，只在字符变更时才构建新字符串
-			if buf == nil {
-				buf = []byte(s)
-			}
-			buf[i] = rc
+	// 查找首个需要转换的位置，在此之前的内容无需修改
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if swarLowerMask(swar.Load64(s, i)) != 0 {
+			break
 		}
 	}
-	if buf == nil {
+	for ; i < len(s); i++ {
+		if ascii.IsLower(s[i]) {
+			break
+		}
+	}
+	if i == len(s) {
 		return s
 	}
+
+	// 懒初始化，只在字符变更时才构建新字符串
+	buf := []byte(s)
+	i &^= 7 // 回退到 word 边界，保证按 8 字节批量处理
+	for ; i+8 <= len(s); i += 8 {
+		swarPut(buf, i, swarToUpper(swar.Load64(s, i)))
+	}
+	for ; i < len(s); i++ {
+		buf[i] = ascii.ToUpper(s[i])
+	}
 	return unsafeBytesToString(buf)
 }
 
 // IsUpper 判断是否全大写
 func IsUpper(s string) bool {
-	for _, c := range []byte(s) {
-		rc := byteToUpper[c]
-		if rc != c {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if swarLowerMask(swar.Load64(s, i)) != 0 {
+			return false
+		}
+	}
+	for ; i < len(s); i++ {
+		if ascii.IsLower(s[i]) {
 			return false
 		}
 	}
@@ -80,28 +67,44 @@ func IsUpper(s string) bool {
 // ToLower 字符串转小写
 // 与标准库 strings.ToLower() 的区别是，它不处理除英文字母外的其他unicode字母
 func ToLower(s string) string {
-	var buf []byte
-	for i, c := range []byte(s) {
-		rc := byteToLower[c]
-		if rc != c {
-			// 懒初始化，只在字符变更时才构建新字符串
-			if buf == nil {
-				buf = []byte(s)
-			}
-			buf[i] = rc
+	// 查找首个需要转换的位置，在此之前的内容无需修改
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if swarUpperMask(swar.Load64(s, i)) != 0 {
+			break
 		}
 	}
-	if buf == nil {
+	for ; i < len(s); i++ {
+		if ascii.IsUpper(s[i]) {
+			break
+		}
+	}
+	if i == len(s) {
 		return s
 	}
+
+	// 懒初始化，只在字符变更时才构建新字符串
+	buf := []byte(s)
+	i &^= 7 // 回退到 word 边界，保证按 8 字节批量处理
+	for ; i+8 <= len(s); i += 8 {
+		swarPut(buf, i, swarToLower(swar.Load64(s, i)))
+	}
+	for ; i < len(s); i++ {
+		buf[i] = ascii.ToLower(s[i])
+	}
 	return unsafeBytesToString(buf)
 }
 
 // IsLower 判断是否全小写
 func IsLower(s string) bool {
-	for _, c := range []byte(s) {
-		rc := byteToLower[c]
-		if rc != c {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		if swarUpperMask(swar.Load64(s, i)) != 0 {
+			return false
+		}
+	}
+	for ; i < len(s); i++ {
+		if ascii.IsUpper(s[i]) {
 			return false
 		}
 	}
@@ -114,9 +117,9 @@ func Capitalize(s string) string {
 	for i, c := range []byte(s) {
 		var rc byte
 		if i == 0 {
-			rc = byteToUpper[c]
+			rc = ascii.ToUpper(c)
 		} else {
-			rc = byteToLower[c]
+			rc = ascii.ToLower(c)
 		}
 
 		if rc != c {
@@ -135,7 +138,7 @@ func Capitalize(s string) string {
 
 // UpperFirst 首字母大写
 func UpperFirst(s string) string {
-	if s == "" || !byteIsLower[s[0]] {
+	if s == "" || !ascii.IsLower(s[0]) {
 		return s
 	}
 	return string(append([]byte{s[0] - 'a' + 'A'}, s[1:]...))
@@ -143,7 +146,7 @@ func UpperFirst(s string) string {
 
 // LowerFirst 首字母小写
 func LowerFirst(s string) string {
-	if s == "" || !byteIsUpper[s[0]] {
+	if s == "" || !ascii.IsUpper(s[0]) {
 		return s
 	}
 	return string(append([]byte{s[0] - 'A' + 'a'}, s[1:]...))
@@ -153,8 +156,21 @@ func LowerFirst(s string) string {
 // 与标准库 strings.Compare() 的区别是，它不处理除英文字母外的其他unicode字母
 func CompareFold(s1 string, s2 string) int {
 	l := min(len(s1), len(s2))
-	for i := 0; i < l; i++ {
-		c1, c2 := byteToLower[s1[i]], byteToLower[s2[i]]
+	i := 0
+	for ; i+8 <= l; i += 8 {
+		w1, w2 := swar.Load64(s1, i), swar.Load64(s2, i)
+		if w1 == w2 {
+			continue
+		}
+		w1, w2 = swarToLower(w1), swarToLower(w2)
+		if w1 != w2 {
+			// 定位首个不同的字节，交由下方逐字节比较
+			i += swarFirstDiff(w1, w2)
+			break
+		}
+	}
+	for ; i < l; i++ {
+		c1, c2 := ascii.ToLower(s1[i]), ascii.ToLower(s2[i])
 		if c1 == c2 {
 			continue
 		} else if c1 < c2 {
@@ -188,67 +204,9 @@ func HasSuffixFold(s string, suffix string) bool {
 	return len(s) >= len(suffix) && CompareFold(s[len(s)-len(suffix):], suffix) == 0
 }
 
-const (
-	stateSeparator = iota // start or ' ' or '-' or '_'
-	stateLower
-	stateUpper
-	stateDigit
-	stateOthers
-)
-
-var byteStates [256]int
-
-func init() {
-	for i := 0; i < len(byteStates); i++ {
-		byteStates[i] = stateOthers
-	}
-	for i := 'A'; i <= 'Z'; i++ {
-		byteStates[i] = stateUpper
-	}
-	for i := 'a'; i <= 'z'; i++ {
-		byteStates[i] = stateLower
-	}
-	for i := '0'; i <= '9'; i++ {
-		byteStates[i] = stateDigit
-	}
-	for _, c := range []byte(" -_") {
-		byteStates[c] = stateSeparator
-	}
-}
-
 // splitWords 分词，返回字符串切分后的单词列表
 func splitWords(s string) []string {
-	var result []string
-	var state int = stateSeparator
-	var wordStart int = 0
-	for i, c := range []byte(s) {
-		nextState := byteStates[c]
-		if state == nextState {
-			continue
-		}
-
-		// AAAaa 形式会拆分为 "AA" + "Aaa"
-		if state == stateUpper && (nextState == stateLower) {
-			if wordStart < i-1 {
-				result = append(result, s[wordStart:i-1])
-			}
-
-			state = nextState
-			wordStart = i - 1
-		} else {
-			if state != stateSeparator {
-				result = append(result, s[wordStart:i])
-			}
-
-			state = nextState
-			wordStart = i
-		}
-
-	}
-	if state != stateSeparator {
-		result = append(result, s[wordStart:])
-	}
-	return result
+	return asciicase.SplitWords(s)
 }
 
 // commonCase 通用的字符串case处理函数
@@ -256,101 +214,35 @@ func splitWords(s string) []string {
 // @param sep 分隔符
 // @param caseHandler 单词case处理函数
 func commonCase(s string, sep string, caseHandler func(i int, word []byte)) string {
-	// 分词
-	words := splitWords(s)
-	if len(words) == 0 {
-		return ""
-	}
-
-	// 预计算结果字符串尺寸
-	size := 0
-	for i, word := range words {
-		if i > 0 {
-			size += len(sep)
-		}
-		size += len(word)
-	}
-
-	//
-	buf := make([]byte, 0, size)
-	for i, word := range words {
-		// 添加分隔符
-		if i > 0 {
-			buf = append(buf, sep...)
-		}
-
-		// 非英文单词不处理大小写
-		if !byteIsAlpha[word[0]] {
-			buf = append(buf, word...)
-			continue
-		}
-
-		// 英文单词处理大小写
-		wordStart := len(buf)
-		buf = append(buf, word...)
-		caseHandler(i, buf[wordStart:])
-	}
-	return unsafeBytesToString(buf)
+	return unsafeBytesToString(asciicase.JoinWords(splitWords(s), sep, caseHandler))
 }
 
 // CamelCase 驼峰命名法(又称小驼峰命名法)，e.g. "userName"
 func CamelCase(s string) string {
-	return commonCase(s, "", func(wordIndex int, word []byte) {
-		for charIndex, c := range word {
-			if charIndex == 0 && wordIndex > 0 {
-				word[charIndex] = byteToUpper[c]
-			} else {
-				word[charIndex] = byteToLower[c]
-			}
-		}
-	})
+	return commonCase(s, "", asciicase.CamelWord)
 }
 
 // PascalCase 帕斯卡命名法(又称大驼峰命名法)，e.g. "UserName"
 func PascalCase(s string) string {
-	return commonCase(s, "", func(wordIndex int, word []byte) {
-		for charIndex, c := range word {
-			if charIndex == 0 {
-				word[charIndex] = byteToUpper[c]
-			} else {
-				word[charIndex] = byteToLower[c]
-			}
-		}
-	})
+	return commonCase(s, "", asciicase.PascalWord)
 }
 
 // SnakeCase 蛇型命名法，e.g. "user_name"
 func SnakeCase(s string) string {
-	return commonCase(s, "_", func(wordIndex int, word []byte) {
-		for charIndex, c := range word {
-			word[charIndex] = byteToLower[c]
-		}
-	})
+	return commonCase(s, "_", asciicase.LowerWord)
 }
 
 // ScreamingSnakeCase 大蛇型命名法，e.g. "USER_NAME"
 func ScreamingSnakeCase(s string) string {
-	return commonCase(s, "_", func(wordIndex int, word []byte) {
-		for charIndex, c := range word {
-			word[charIndex] = byteToUpper[c]
-		}
-	})
+	return commonCase(s, "_", asciicase.UpperWord)
 }
 
 // KebabCase 烤串式命名法，e.g. "user-name"
 func KebabCase(s string) string {
-	return commonCase(s, "-", func(wordIndex int, word []byte) {
-		for charIndex, c := range word {
-			word[charIndex] = byteToLower[c]
-		}
-	})
+	return commonCase(s, "-", asciicase.LowerWord)
 }
 
 // KebabCase 大烤串式命名法，e.g. "USER-NAME"
 func ScreamingKebabCase(s string) string {
-	return commonCase(s, "-", func(wordIndex int, word []byte) {
-		for charIndex, c := range word {
-			word[charIndex] = byteToUpper[c]
-		}
-	})
+	return commonCase(s, "-", asciicase.UpperWord)
 }
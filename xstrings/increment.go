@@ -0,0 +1,119 @@
+package xstrings
+
+// 本文件内是 PHP 字符串自增/自减相关的函数，采用 Perl 风格的字母数字进位规则:
+// - 'a'~'z'、'A'~'Z'、'0'~'9' 三类字符各自循环，e.g. "Az" => "Ba"、"a9" => "b0"
+// - 最高位进位时按最高位字符的类别在前面补充 'a'、'A' 或 '1'，e.g. "zz" => "aaa"、"Zz" => "AAa"、"99" => "100"
+
+import (
+	"errors"
+	"github.com/heyuuu/gophp-utils/ascii"
+)
+
+var (
+	ErrEmptyString       = errors.New("xstrings: string cannot be empty")
+	ErrNotAlphanumeric   = errors.New("xstrings: string must be composed only of alphanumeric ASCII characters")
+	ErrDecrementOutRange = errors.New("xstrings: string is out of decrement range")
+)
+
+// Increment 字符串自增，对应 PHP 中对非数字字符串执行 $s++ 的旧有行为
+// - 空字符串返回 "1"
+// - 从末尾向前进位，遇到非字母数字字符时停止进位，e.g. "a-z" => "a-a"、"-" => "-"
+// 注意: PHP 中数字字符串的 $s++ 按数值自增，调用方需自行处理
+func Increment(s string) string {
+	if s == "" {
+		return "1"
+	}
+
+	buf := []byte(s)
+	carry := false
+	var last byte
+	for pos := len(buf) - 1; pos >= 0; pos-- {
+		c := buf[pos]
+		switch {
+		case ascii.IsLower(c):
+			carry, buf[pos], last = c == 'z', nextChar(c, 'a', 'z'), 'a'
+		case ascii.IsUpper(c):
+			carry, buf[pos], last = c == 'Z', nextChar(c, 'A', 'Z'), 'A'
+		case ascii.IsDigit(c):
+			carry, buf[pos], last = c == '9', nextChar(c, '0', '9'), '1'
+		default:
+			carry = false
+		}
+		if !carry {
+			break
+		}
+	}
+
+	if carry {
+		// 最高位进位，按最高位字符的类别补充首字符
+		buf = append([]byte{last}, buf...)
+	}
+	return unsafeBytesToString(buf)
+}
+
+// nextChar 返回 [lo, hi] 范围内循环的下一个字符
+func nextChar(c byte, lo, hi byte) byte {
+	if c == hi {
+		return lo
+	}
+	return c + 1
+}
+
+// checkAlphanumeric 校验字符串非空且仅由 ASCII 字母数字组成
+func checkAlphanumeric(s string) error {
+	if s == "" {
+		return ErrEmptyString
+	}
+	for _, c := range []byte(s) {
+		if !ascii.IsAlphaNum(c) {
+			return ErrNotAlphanumeric
+		}
+	}
+	return nil
+}
+
+// StrIncrement 字符串自增，对应 PHP 8.3 函数 str_increment()
+// 字符串为空或包含非 ASCII 字母数字字符时返回错误
+func StrIncrement(s string) (string, error) {
+	if err := checkAlphanumeric(s); err != nil {
+		return "", err
+	}
+	return Increment(s), nil
+}
+
+// StrDecrement 字符串自减，对应 PHP 8.3 函数 str_decrement()
+// - 字符串为空或包含非 ASCII 字母数字字符时返回错误
+// - 从末尾向前借位，最高位借位或结果以 '0' 开头时去除首字符，e.g. "aa" => "z"、"10" => "9"
+// - "a"、"A"、"0" 等无法再自减的字符串返回 ErrDecrementOutRange
+func StrDecrement(s string) (string, error) {
+	if err := checkAlphanumeric(s); err != nil {
+		return "", err
+	}
+
+	buf := []byte(s)
+	borrow := false
+	for pos := len(buf) - 1; pos >= 0; pos-- {
+		c := buf[pos]
+		switch c {
+		case 'a':
+			borrow, buf[pos] = true, 'z'
+		case 'A':
+			borrow, buf[pos] = true, 'Z'
+		case '0':
+			borrow, buf[pos] = true, '9'
+		default:
+			borrow, buf[pos] = false, c-1
+		}
+		if !borrow {
+			break
+		}
+	}
+
+	if borrow || (buf[0] == '0' && len(buf) > 1) {
+		if len(buf) == 1 {
+			return "", ErrDecrementOutRange
+		}
+		buf = buf[1:]
+	}
+	return unsafeBytesToString(buf), nil
+}
@@ -0,0 +1,197 @@
+package xstrings
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestIncrement(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{"", "", "1"},
+		{"", "a", "b"},
+		{"", "Az", "Ba"},
+		{"", "zz", "aaa"},
+		{"", "Zz", "AAa"},
+		{"", "zZ", "aaA"},
+		{"", "a9", "b0"},
+		{"", "9z", "10a"},
+		{"", "z9", "aa0"},
+		{"", "a-z", "a-a"},
+		{"", "a-9", "a-0"},
+		{"", "-", "-"},
+		{"", "z-", "z-"},
+		{"", "用户z", "用户a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Increment(tt.arg); got != tt.want {
+				t.Errorf("Increment(%q) = %q, want %q", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrIncrementError(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		wantErr error
+	}{
+		{"", "", ErrEmptyString},
+		{"", "a-z", ErrNotAlphanumeric},
+		{"", " 1", ErrNotAlphanumeric},
+		{"", "é", ErrNotAlphanumeric},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := StrIncrement(tt.arg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("StrIncrement(%q) error = %v, want %v", tt.arg, err, tt.wantErr)
+			}
+			if _, err := StrDecrement(tt.arg); !errors.Is(err, tt.wantErr) {
+				t.Errorf("StrDecrement(%q) error = %v, want %v", tt.arg, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStrDecrement(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    string
+		wantErr error
+	}{
+		{"", "b", "a", nil},
+		{"", "Ba", "Az", nil},
+		{"", "aaa", "zz", nil},
+		{"", "AAa", "Zz", nil},
+		{"", "b0", "a9", nil},
+		{"", "10", "9", nil},
+		{"", "100", "99", nil},
+		{"", "1", "0", nil},
+		{"", "a", "", ErrDecrementOutRange},
+		{"", "A", "", ErrDecrementOutRange},
+		{"", "0", "", ErrDecrementOutRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StrDecrement(tt.arg)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("StrDecrement(%q) = %q, %v, want %q, %v", tt.arg, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// 单一字符类别的字符串等价于数值: 数字串为十进制数，字母串为双射 26 进制数(a=1, ..., z=26)
+// 以此作为独立的参考模型，自增/自减即数值加减 1
+
+func bijectiveToInt(s string, first byte) int {
+	n := 0
+	for _, c := range []byte(s) {
+		n = n*26 + int(c-first) + 1
+	}
+	return n
+}
+
+func intToBijective(n int, first byte) string {
+	var buf []byte
+	for n > 0 {
+		n--
+		buf = append([]byte{first + byte(n%26)}, buf...)
+		n /= 26
+	}
+	return string(buf)
+}
+
+func TestStrIncrementSingleClassExhaustive(t *testing.T) {
+	for _, first := range []byte{'a', 'A'} {
+		// 所有长度 1~3 的字母串
+		for n := 1; n <= 26+26*26+26*26*26; n++ {
+			s := intToBijective(n, first)
+			want := intToBijective(n+1, first)
+			if got, err := StrIncrement(s); got != want || err != nil {
+				t.Fatalf("StrIncrement(%q) = %q, %v, want %q", s, got, err, want)
+			}
+			wantDec := intToBijective(n-1, first)
+			got, err := StrDecrement(s)
+			if n == 1 {
+				if !errors.Is(err, ErrDecrementOutRange) {
+					t.Fatalf("StrDecrement(%q) error = %v, want %v", s, err, ErrDecrementOutRange)
+				}
+			} else if got != wantDec || err != nil {
+				t.Fatalf("StrDecrement(%q) = %q, %v, want %q", s, got, err, wantDec)
+			}
+			if back := bijectiveToInt(s, first); back != n {
+				t.Fatalf("bijectiveToInt(%q) = %v, want %v", s, back, n)
+			}
+		}
+	}
+
+	// 所有长度 1~4 的无前导 0 的数字串
+	for n := 0; n < 10000; n++ {
+		s := strconv.Itoa(n)
+		if got, err := StrIncrement(s); got != strconv.Itoa(n+1) || err != nil {
+			t.Fatalf("StrIncrement(%q) = %q, %v, want %q", s, got, err, strconv.Itoa(n+1))
+		}
+		got, err := StrDecrement(s)
+		if n == 0 {
+			if !errors.Is(err, ErrDecrementOutRange) {
+				t.Fatalf("StrDecrement(%q) error = %v, want %v", s, err, ErrDecrementOutRange)
+			}
+		} else if got != strconv.Itoa(n-1) || err != nil {
+			t.Fatalf("StrDecrement(%q) = %q, %v, want %q", s, got, err, strconv.Itoa(n-1))
+		}
+	}
+}
+
+// refIncrement 递归形式的参考模型: 末位不进位时直接加一，否则对前缀递归自增
+func refIncrement(s string) string {
+	if s == "" {
+		return "1"
+	}
+	prefix, c := s[:len(s)-1], s[len(s)-1]
+	var lo, hi, carryChar byte
+	switch {
+	case 'a' <= c && c <= 'z':
+		lo, hi, carryChar = 'a', 'z', 'a'
+	case 'A' <= c && c <= 'Z':
+		lo, hi, carryChar = 'A', 'Z', 'A'
+	case '0' <= c && c <= '9':
+		lo, hi, carryChar = '0', '9', '1'
+	default:
+		return s
+	}
+	if c != hi {
+		return prefix + string(c+1)
+	}
+	if prefix == "" {
+		return string(carryChar) + string(lo)
+	}
+	if last := prefix[len(prefix)-1]; !('a' <= last && last <= 'z' || 'A' <= last && last <= 'Z' || '0' <= last && last <= '9') {
+		return prefix + string(lo)
+	}
+	return refIncrement(prefix) + string(lo)
+}
+
+func TestIncrementMixedExhaustive(t *testing.T) {
+	alphabet := []byte("0189abyzABYZ-_ \x00\x80\xff")
+	var check func(s string, depth int)
+	check = func(s string, depth int) {
+		if got, want := Increment(s), refIncrement(s); got != want {
+			t.Fatalf("Increment(%q) = %q, want %q", s, got, want)
+		}
+		if depth == 4 {
+			return
+		}
+		for _, c := range alphabet {
+			check(s+string([]byte{c}), depth+1)
+		}
+	}
+	check("", 0)
+}
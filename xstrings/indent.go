@@ -5,30 +5,30 @@ import (
 	"strings"
 )
 
+// indentWidth 返回行首空白字符的长度
 func indentWidth(s string) int {
-	for i, c := range []byte(s) {
-		if !ascii.IsSpace(c) {
-			return i
-		}
+	if i := ascii.IndexNonSpace(s); i >= 0 {
+		return i
 	}
 	return len(s)
 }
 
 // TrimIndent 去除多行字符串的前置缩进，多用于代码中大段文本的美化表示
 // 去除缩进长度为所有非空白行缩进长度的最小值；空白行缩进长度不够时置空；若首尾行为空行则移除
+// 支持 "\n"、"\r\n"、"\r" 三种换行符，各行保留原有换行符
 func TrimIndent(s string) string {
-	lines := strings.Split(s, "\n")
+	lines := splitLines(s)
 
 	// 计算共同缩进长度
 	commonIndent := -1
-	for _, line := range lines {
+	for _, l := range lines {
 		// 跳过空白行
-		if IsBlank(line) {
+		if IsBlank(l.line) {
 			continue
 		}
 
 		// 非空白行计算共同缩进长度
-		indent := indentWidth(line)
+		indent := indentWidth(l.line)
 		if commonIndent < 0 {
 			commonIndent = indent
 		} else {
@@ -42,38 +42,47 @@ func TrimIndent(s string) string {
 	}
 
 	// 首行和末行若为空白行，则移除
-	if len(lines) > 0 && IsBlank(lines[0]) {
+	if len(lines) > 0 && IsBlank(lines[0].line) {
 		lines = lines[1:]
 	}
-	if len(lines) > 0 && IsBlank(lines[len(lines)-1]) {
+	if len(lines) > 0 && IsBlank(lines[len(lines)-1].line) {
 		lines = lines[:len(lines)-1]
 	}
 
-	// 逐行修改
-	for i, line := range lines {
-		if commonIndent < len(line) {
-			lines[i] = line[commonIndent:]
-		} else {
-			lines[i] = ""
+	// 逐行修改，末行不保留换行符
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for i, l := range lines {
+		if commonIndent < len(l.line) {
+			buf.WriteString(l.line[commonIndent:])
+		}
+		if i < len(lines)-1 {
+			buf.WriteString(l.newline)
 		}
 	}
-	return strings.Join(lines, "\n")
+	return buf.String()
 }
 
 // PrependIndent 给多行字符串添加同一个前缀
+// 各行保留原有换行符，末行无换行符时补充与首个换行符相同风格的换行符
 func PrependIndent(s string, prefix string) string {
 	if prefix == "" {
 		return s
 	}
 
-	lines := strings.Split(s, "\n")
+	lines := splitLines(s)
+	style, _ := DetectNewline(s)
 
 	var buf strings.Builder
-	buf.Grow(len(s) + len(lines)*len(prefix))
-	for _, line := range lines {
+	buf.Grow(len(s) + len(lines)*(len(prefix)+1))
+	for _, l := range lines {
 		buf.WriteString(prefix)
-		buf.WriteString(line)
-		buf.WriteString("\n")
+		buf.WriteString(l.line)
+		if l.newline != "" {
+			buf.WriteString(l.newline)
+		} else {
+			buf.WriteString(style.String())
+		}
 	}
 	return buf.String()
 }
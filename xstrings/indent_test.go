@@ -23,6 +23,14 @@ func TestTrimIndent(t *testing.T) {
 			`,
 			want: "line1\n\tline2\n\t\tline3",
 		},
+		{
+			arg:  "\r\n\tline1\r\n\t\tline2\r\n",
+			want: "line1\r\n\tline2",
+		},
+		{
+			arg:  "\tline1\r\n\t\tline2\r\tline3\n",
+			want: "line1\r\n\tline2\rline3",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -32,3 +40,25 @@ func TestTrimIndent(t *testing.T) {
 		})
 	}
 }
+
+func TestPrependIndent(t *testing.T) {
+	tests := []struct {
+		name   string
+		arg    string
+		prefix string
+		want   string
+	}{
+		{"", "line1\nline2", "", "line1\nline2"},
+		{"", "line1\nline2", "\t", "\tline1\n\tline2\n"},
+		{"", "line1\nline2\n", "\t", "\tline1\n\tline2\n\t\n"},
+		{"", "line1\r\nline2", "\t", "\tline1\r\n\tline2\r\n"},
+		{"", "line1\rline2\nline3", "\t", "\tline1\r\tline2\n\tline3\r"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PrependIndent(tt.arg, tt.prefix); got != tt.want {
+				t.Errorf("PrependIndent() = %v, want %v", strconv.Quote(got), strconv.Quote(tt.want))
+			}
+		})
+	}
+}
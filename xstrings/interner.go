@@ -0,0 +1,190 @@
+package xstrings
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"hash/maphash"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+	"weak"
+)
+
+// internShardCount 分片数，降低并发场景下的锁竞争
+const internShardCount = 32
+
+// Interner 字符串驻留池，对相同内容的字符串返回同一个规范字符串(共享底层内存)，并发安全
+// 池中只持有规范字符串的弱引用，当规范字符串不再被使用时会被 GC 回收，并自动从池中移除
+type Interner struct {
+	fold   bool
+	seed   maphash.Seed
+	shards [internShardCount]internShard
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type internShard struct {
+	mu      sync.Mutex
+	buckets map[uint64][]internEntry
+	entries int
+	bytes   int
+}
+
+// internEntry 规范字符串的弱引用，指向字符串底层数据的首字节
+type internEntry struct {
+	data weak.Pointer[byte]
+	size int
+}
+
+// value 返回规范字符串，已被回收时返回 false
+func (e internEntry) value() (string, bool) {
+	p := e.data.Value()
+	if p == nil {
+		return "", false
+	}
+	return unsafe.String(p, e.size), true
+}
+
+// InternerStats 驻留池统计信息
+type InternerStats struct {
+	Hits    uint64 // 命中次数
+	Misses  uint64 // 未命中次数(即新建规范字符串的次数)
+	Entries int    // 当前池中的条目数(含已不再使用但尚未被 GC 回收的条目)
+	Bytes   int    // 当前池中规范字符串的总字节数
+}
+
+// NewInterner 创建字符串驻留池
+func NewInterner() *Interner {
+	return &Interner{seed: maphash.MakeSeed()}
+}
+
+// NewFoldInterner 创建忽略 ASCII 大小写的字符串驻留池，规范字符串为全小写形式
+// 适用于 PHP 中大小写不敏感的标识符，e.g. 函数名、类名
+func NewFoldInterner() *Interner {
+	return &Interner{fold: true, seed: maphash.MakeSeed()}
+}
+
+// Intern 返回与 s 相等的规范字符串；忽略大小写的驻留池返回 s 转小写后对应的规范字符串
+// 命中时不会分配内存
+func (in *Interner) Intern(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	h := in.hash(s)
+	shard := &in.shards[h%internShardCount]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if v, ok := shard.lookupLocked(h, s, in.fold); ok {
+		in.hits.Add(1)
+		return v
+	}
+
+	in.misses.Add(1)
+	var v string
+	if in.fold {
+		v = ToLower(s)
+	}
+	if v == "" || unsafe.StringData(v) == unsafe.StringData(s) {
+		v = strings.Clone(s) // 复制一份，避免持有调用方的大字符串
+	}
+	shard.insertLocked(h, v)
+	return v
+}
+
+// InternBytes 类似 Intern()，参数为字节切片；命中时不会分配内存
+func (in *Interner) InternBytes(b []byte) string {
+	// 只读使用，查找过程中不保存对 b 的引用
+	return in.Intern(unsafe.String(unsafe.SliceData(b), len(b)))
+}
+
+// Stats 返回驻留池统计信息
+func (in *Interner) Stats() InternerStats {
+	stats := InternerStats{
+		Hits:   in.hits.Load(),
+		Misses: in.misses.Load(),
+	}
+	for i := range in.shards {
+		shard := &in.shards[i]
+		shard.mu.Lock()
+		stats.Entries += shard.entries
+		stats.Bytes += shard.bytes
+		shard.mu.Unlock()
+	}
+	return stats
+}
+
+// hash 计算哈希值，忽略大小写时按转小写后的内容计算
+func (in *Interner) hash(s string) uint64 {
+	if !in.fold {
+		return maphash.String(in.seed, s)
+	}
+
+	var h maphash.Hash
+	h.SetSeed(in.seed)
+	var buf [64]byte
+	for len(s) > 0 {
+		n := min(len(s), len(buf))
+		for i := 0; i < n; i++ {
+			buf[i] = ascii.ToLower(s[i])
+		}
+		h.Write(buf[:n])
+		s = s[n:]
+	}
+	return h.Sum64()
+}
+
+func (shard *internShard) lookupLocked(h uint64, s string, fold bool) (string, bool) {
+	for _, entry := range shard.buckets[h] {
+		v, ok := entry.value()
+		if !ok || len(v) != len(s) {
+			continue
+		}
+		if v == s || (fold && EqualFold(v, s)) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func (shard *internShard) insertLocked(h uint64, v string) {
+	if shard.buckets == nil {
+		shard.buckets = make(map[uint64][]internEntry)
+	}
+	shard.purgeLocked(h)
+
+	data := unsafe.StringData(v)
+	shard.buckets[h] = append(shard.buckets[h], internEntry{data: weak.Make(data), size: len(v)})
+	shard.entries++
+	shard.bytes += len(v)
+
+	// 规范字符串被回收后清理对应条目
+	runtime.AddCleanup(data, func(h uint64) {
+		shard.mu.Lock()
+		shard.purgeLocked(h)
+		shard.mu.Unlock()
+	}, h)
+}
+
+// purgeLocked 移除指定哈希桶中已被回收的条目
+func (shard *internShard) purgeLocked(h uint64) {
+	bucket := shard.buckets[h]
+	alive := bucket[:0]
+	for _, entry := range bucket {
+		if entry.data.Value() != nil {
+			alive = append(alive, entry)
+		} else {
+			shard.entries--
+			shard.bytes -= entry.size
+		}
+	}
+	clear(bucket[len(alive):])
+
+	if len(alive) == 0 {
+		delete(shard.buckets, h)
+	} else {
+		shard.buckets[h] = alive
+	}
+}
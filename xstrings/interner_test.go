@@ -0,0 +1,129 @@
+package xstrings
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestInterner(t *testing.T) {
+	in := NewInterner()
+
+	s1 := in.Intern(strings.Clone("functionName"))
+	s2 := in.Intern(strings.Clone("functionName"))
+	s3 := in.InternBytes([]byte("functionName"))
+	s4 := in.Intern("FunctionName")
+	if s1 != "functionName" || s4 != "FunctionName" {
+		t.Fatalf("Intern() = %q, %q", s1, s4)
+	}
+	if unsafe.StringData(s1) != unsafe.StringData(s2) || unsafe.StringData(s1) != unsafe.StringData(s3) {
+		t.Errorf("Intern() did not return canonical string")
+	}
+	if unsafe.StringData(s1) == unsafe.StringData(s4) {
+		t.Errorf("Intern() should be case-sensitive")
+	}
+	if got := in.Intern(""); got != "" {
+		t.Errorf("Intern(\"\") = %q", got)
+	}
+
+	stats := in.Stats()
+	if stats.Hits != 2 || stats.Misses != 2 || stats.Entries != 2 || stats.Bytes != 24 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	runtime.KeepAlive(s1)
+	runtime.KeepAlive(s4)
+}
+
+func TestFoldInterner(t *testing.T) {
+	in := NewFoldInterner()
+
+	s1 := in.Intern("StrLen")
+	s2 := in.Intern("STRLEN")
+	s3 := in.InternBytes([]byte("strlen"))
+	if s1 != "strlen" {
+		t.Fatalf("Intern() = %q, want %q", s1, "strlen")
+	}
+	if unsafe.StringData(s1) != unsafe.StringData(s2) || unsafe.StringData(s1) != unsafe.StringData(s3) {
+		t.Errorf("Intern() did not return canonical string")
+	}
+	if got := in.Intern("用户のID"); got != "用户のid" {
+		t.Errorf("Intern() = %q, want %q", got, "用户のid")
+	}
+
+	stats := in.Stats()
+	if stats.Hits != 2 || stats.Misses != 2 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestInternerNoAllocOnHit(t *testing.T) {
+	for _, in := range []*Interner{NewInterner(), NewFoldInterner()} {
+		canonical := in.Intern("className")
+		key := []byte("className")
+		allocs := testing.AllocsPerRun(100, func() {
+			in.Intern("className")
+			in.InternBytes(key)
+		})
+		if allocs != 0 {
+			t.Errorf("Intern() allocs = %v, want 0", allocs)
+		}
+		runtime.KeepAlive(canonical)
+	}
+}
+
+func TestInternerConcurrent(t *testing.T) {
+	in := NewFoldInterner()
+	const goroutines, names = 8, 1000
+
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < names; i++ {
+				name := "Name_" + strconv.Itoa(i)
+				if g%2 == 1 {
+					name = strings.ToUpper(name)
+				}
+				results[g] = append(results[g], in.Intern(name))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for g := 1; g < goroutines; g++ {
+		for i := 0; i < names; i++ {
+			if unsafe.StringData(results[g][i]) != unsafe.StringData(results[0][i]) {
+				t.Fatalf("Intern() results differ between goroutines for %q", results[0][i])
+			}
+		}
+	}
+	if stats := in.Stats(); stats.Misses != names || stats.Hits != (goroutines-1)*names {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestInternerReclaim(t *testing.T) {
+	in := NewInterner()
+	for i := 0; i < 100; i++ {
+		in.Intern("a reasonably long identifier name #" + strconv.Itoa(i))
+	}
+	if stats := in.Stats(); stats.Entries != 100 {
+		t.Fatalf("Stats().Entries = %v, want 100", stats.Entries)
+	}
+
+	// 清理函数在 GC 后异步执行，等待一段时间
+	deadline := time.Now().Add(5 * time.Second)
+	for in.Stats().Entries > 0 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stats := in.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("Stats() after GC = %+v, want no entries", stats)
+	}
+}
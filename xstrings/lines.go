@@ -0,0 +1,119 @@
+package xstrings
+
+// 本文件内是按行处理字符串相关的函数。
+// 同时识别三种换行符: "\n"(Unix)、"\r\n"(Windows) 和 "\r"(经典 Mac OS)，"\r\n" 视为一个整体。
+
+import (
+	"iter"
+	"strings"
+)
+
+// NewlineStyle 换行符风格
+type NewlineStyle int
+
+const (
+	NewlineLF   NewlineStyle = iota // "\n"
+	NewlineCRLF                     // "\r\n"
+	NewlineCR                       // "\r"
+)
+
+// String 返回换行符风格对应的换行符
+func (style NewlineStyle) String() string {
+	switch style {
+	case NewlineCRLF:
+		return "\r\n"
+	case NewlineCR:
+		return "\r"
+	default:
+		return "\n"
+	}
+}
+
+// nextLine 查找 s 中第一个换行符，返回行内容长度和换行符长度；无换行符时返回 len(s), 0
+func nextLine(s string) (lineLen int, termLen int) {
+	i := strings.IndexAny(s, "\r\n")
+	if i < 0 {
+		return len(s), 0
+	}
+	if s[i] == '\r' && i+1 < len(s) && s[i+1] == '\n' {
+		return i, 2
+	}
+	return i, 1
+}
+
+// Lines 按行遍历字符串，依次返回每行内容(不含换行符)及其换行符
+// 最后一行无换行符时返回的换行符为空字符串；字符串以换行符结尾时，不会额外返回末尾的空行
+// 返回值均为原字符串的子串，遍历过程中不会分配内存
+func Lines(s string) iter.Seq2[string, string] {
+	return func(yield func(line string, newline string) bool) {
+		for len(s) > 0 {
+			lineLen, termLen := nextLine(s)
+			if !yield(s[:lineLen], s[lineLen:lineLen+termLen]) {
+				return
+			}
+			s = s[lineLen+termLen:]
+		}
+	}
+}
+
+// DetectNewline 检测字符串使用的换行符风格，以第一个出现的换行符为准
+// 字符串中不含换行符时返回 NewlineLF, false
+func DetectNewline(s string) (NewlineStyle, bool) {
+	lineLen, termLen := nextLine(s)
+	switch {
+	case termLen == 0:
+		return NewlineLF, false
+	case termLen == 2:
+		return NewlineCRLF, true
+	case s[lineLen] == '\r':
+		return NewlineCR, true
+	default:
+		return NewlineLF, true
+	}
+}
+
+// NormalizeNewlines 将字符串中所有换行符统一替换为指定风格
+// 无需修改时直接返回原字符串
+func NormalizeNewlines(s string, style NewlineStyle) string {
+	newline := style.String()
+
+	// 检查是否已是指定风格，避免无谓的内存分配
+	normalized := true
+	for _, term := range Lines(s) {
+		if term != "" && term != newline {
+			normalized = false
+			break
+		}
+	}
+	if normalized {
+		return s
+	}
+
+	var buf strings.Builder
+	buf.Grow(len(s))
+	for line, term := range Lines(s) {
+		buf.WriteString(line)
+		if term != "" {
+			buf.WriteString(newline)
+		}
+	}
+	return buf.String()
+}
+
+// lineEntry 行内容及其换行符
+type lineEntry struct {
+	line    string
+	newline string
+}
+
+// splitLines 按行切分字符串，结果与 strings.Split(s, "\n") 对应：以换行符结尾时末尾包含一个空行
+func splitLines(s string) []lineEntry {
+	var lines []lineEntry
+	for line, term := range Lines(s) {
+		lines = append(lines, lineEntry{line, term})
+	}
+	if len(lines) == 0 || lines[len(lines)-1].newline != "" {
+		lines = append(lines, lineEntry{})
+	}
+	return lines
+}
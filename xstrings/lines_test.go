@@ -0,0 +1,104 @@
+package xstrings
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want [][2]string
+	}{
+		{"", "", nil},
+		{"", "line", [][2]string{{"line", ""}}},
+		{"", "line\n", [][2]string{{"line", "\n"}}},
+		{"", "a\nb\r\nc\rd", [][2]string{{"a", "\n"}, {"b", "\r\n"}, {"c", "\r"}, {"d", ""}}},
+		{"", "\n\r\n\r", [][2]string{{"", "\n"}, {"", "\r\n"}, {"", "\r"}}},
+		{"", "a\r\r\nb\n\r", [][2]string{{"a", "\r"}, {"", "\r\n"}, {"b", "\n"}, {"", "\r"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]string
+			for line, newline := range Lines(tt.arg) {
+				got = append(got, [2]string{line, newline})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinesBreak(t *testing.T) {
+	var got []string
+	for line := range Lines("a\nb\nc") {
+		got = append(got, line)
+		if line == "b" {
+			break
+		}
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+}
+
+func TestLinesNoAlloc(t *testing.T) {
+	s := "line1\r\nline2\nline3\rline4"
+	allocs := testing.AllocsPerRun(100, func() {
+		for range Lines(s) {
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Lines() allocs = %v, want 0", allocs)
+	}
+}
+
+func TestDetectNewline(t *testing.T) {
+	tests := []struct {
+		name      string
+		arg       string
+		want      NewlineStyle
+		wantFound bool
+	}{
+		{"", "", NewlineLF, false},
+		{"", "line", NewlineLF, false},
+		{"", "a\nb\r\n", NewlineLF, true},
+		{"", "a\r\nb\n", NewlineCRLF, true},
+		{"", "a\rb\n", NewlineCR, true},
+		{"", "a\r", NewlineCR, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := DetectNewline(tt.arg)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("DetectNewline() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := []struct {
+		name  string
+		arg   string
+		style NewlineStyle
+		want  string
+	}{
+		{"", "", NewlineCRLF, ""},
+		{"", "a\nb\r\nc\rd", NewlineLF, "a\nb\nc\nd"},
+		{"", "a\nb\r\nc\rd", NewlineCRLF, "a\r\nb\r\nc\r\nd"},
+		{"", "a\nb\r\nc\rd", NewlineCR, "a\rb\rc\rd"},
+		{"", "a\r\r\n", NewlineLF, "a\n\n"},
+		{"", "a\nb\n", NewlineLF, "a\nb\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeNewlines(tt.arg, tt.style); got != tt.want {
+				t.Errorf("NormalizeNewlines() = %v, want %v", strconv.Quote(got), strconv.Quote(tt.want))
+			}
+		})
+	}
+}
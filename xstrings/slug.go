@@ -0,0 +1,144 @@
+package xstrings
+
+// 本文件内是生成 URL slug、文件名等场景使用的 Slugify 函数
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+	"github.com/heyuuu/gophp-utils/internal/asciicase"
+	"unicode"
+	"unicode/utf8"
+)
+
+// latinTranslit Latin-1 补充(U+00C0~U+00FF)及拉丁文扩展-A(U+0100~U+017F)字母的 ASCII 转写表，以 r-0xC0 为下标
+// 空字符串表示非字母(× ÷)，按分隔符处理
+var latinTranslit = [...]string{
+	"A", "A", "A", "A", "A", "A", "AE", "C", // À Á Â Ã Ä Å Æ Ç
+	"E", "E", "E", "E", "I", "I", "I", "I", // È É Ê Ë Ì Í Î Ï
+	"D", "N", "O", "O", "O", "O", "O", "", // Ð Ñ Ò Ó Ô Õ Ö ×
+	"O", "U", "U", "U", "U", "Y", "TH", "ss", // Ø Ù Ú Û Ü Ý Þ ß
+	"a", "a", "a", "a", "a", "a", "ae", "c", // à á â ã ä å æ ç
+	"e", "e", "e", "e", "i", "i", "i", "i", // è é ê ë ì í î ï
+	"d", "n", "o", "o", "o", "o", "o", "", // ð ñ ò ó ô õ ö ÷
+	"o", "u", "u", "u", "u", "y", "th", "y", // ø ù ú û ü ý þ ÿ
+	"A", "a", "A", "a", "A", "a", "C", "c", // Ā ā Ă ă Ą ą Ć ć
+	"C", "c", "C", "c", "C", "c", "D", "d", // Ĉ ĉ Ċ ċ Č č Ď ď
+	"D", "d", "E", "e", "E", "e", "E", "e", // Đ đ Ē ē Ĕ ĕ Ė ė
+	"E", "e", "E", "e", "G", "g", "G", "g", // Ę ę Ě ě Ĝ ĝ Ğ ğ
+	"G", "g", "G", "g", "H", "h", "H", "h", // Ġ ġ Ģ ģ Ĥ ĥ Ħ ħ
+	"I", "i", "I", "i", "I", "i", "I", "i", // Ĩ ĩ Ī ī Ĭ ĭ Į į
+	"I", "i", "IJ", "ij", "J", "j", "K", "k", // İ ı Ĳ ĳ Ĵ ĵ Ķ ķ
+	"k", "L", "l", "L", "l", "L", "l", "L", // ĸ Ĺ ĺ Ļ ļ Ľ ľ Ŀ
+	"l", "L", "l", "N", "n", "N", "n", "N", // ŀ Ł ł Ń ń Ņ ņ Ň
+	"n", "n", "N", "n", "O", "o", "O", "o", // ň ŉ Ŋ ŋ Ō ō Ŏ ŏ
+	"O", "o", "OE", "oe", "R", "r", "R", "r", // Ő ő Œ œ Ŕ ŕ Ŗ ŗ
+	"R", "r", "S", "s", "S", "s", "S", "s", // Ř ř Ś ś Ŝ ŝ Ş ş
+	"S", "s", "T", "t", "T", "t", "T", "t", // Š š Ţ ţ Ť ť Ŧ ŧ
+	"U", "u", "U", "u", "U", "u", "U", "u", // Ũ ũ Ū ū Ŭ ŭ Ů ů
+	"U", "u", "U", "u", "W", "w", "Y", "y", // Ű ű Ų ų Ŵ ŵ Ŷ ŷ
+	"Y", "Z", "z", "Z", "z", "Z", "z", "s", // Ÿ Ź ź Ż ż Ž ž ſ
+}
+
+// translitRune 返回字符的 ASCII 转写，无法转写时返回 false
+func translitRune(r rune) (string, bool) {
+	if r >= 0xC0 && int(r-0xC0) < len(latinTranslit) {
+		t := latinTranslit[r-0xC0]
+		return t, t != ""
+	}
+	// 拉丁文扩展-B 及扩展附加中的常用字母
+	switch r {
+	case 'Ș':
+		return "S", true
+	case 'ș':
+		return "s", true
+	case 'Ț':
+		return "T", true
+	case 'ț':
+		return "t", true
+	case 'ƒ':
+		return "f", true
+	case 'Ə':
+		return "E", true
+	case 'ə':
+		return "e", true
+	case 'ẞ':
+		return "SS", true
+	}
+	return "", false
+}
+
+// transliterate 将 s 转写为只包含 ASCII 字母、数字和空格的字节序列，其他字符均替换为空格
+// 多字母的大写转写在后接小写字母时使用首字母大写形式，避免分词时被拆开，e.g. "Æon" => "Aeon"、"ÆON" => "AEON"
+func transliterate(s string) []byte {
+	buf := make([]byte, 0, len(s))
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if !ascii.IsAlphaNum(c) {
+				c = ' '
+			}
+			buf = append(buf, c)
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		t, ok := translitRune(r)
+		if !ok {
+			buf = append(buf, ' ')
+			continue
+		}
+		start := len(buf)
+		buf = append(buf, t...)
+		if len(t) > 1 && ascii.IsUpper(t[0]) {
+			if next, _ := utf8.DecodeRuneInString(s[i:]); unicode.IsLower(next) {
+				asciicase.LowerWord(0, buf[start+1:])
+			}
+		}
+	}
+	return buf
+}
+
+// SlugOptions Slugify 选项
+type SlugOptions struct {
+	// Separator 单词分隔符，为空时使用 "-"
+	Separator string
+	// MaxLength 结果的最大字节长度，<= 0 时不限制
+	// 超出时在单词边界处截断；首个单词即超出长度时截断该单词
+	MaxLength int
+}
+
+// Slugify 生成 URL slug，e.g. "Crème Brûlée Recipes!" => "creme-brulee-recipes"
+// - 常用拉丁字母转写为 ASCII，e.g. "é" => "e"、"ß" => "ss"、"Ø" => "O"
+// - 其他字符均视为分隔符，连续分隔符合并为一个
+// - 分词规则与 KebabCase/SnakeCase 一致，e.g. "HTTPServer" => "http-server"；结果均为小写
+func Slugify(s string, opts SlugOptions) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	words := asciicase.SplitWords(transliterate(s))
+	if opts.MaxLength > 0 {
+		words = truncateWords(words, len(sep), opts.MaxLength)
+	}
+	return unsafeBytesToString(asciicase.JoinWords(words, sep, asciicase.LowerWord))
+}
+
+// truncateWords 保留按分隔符连接后总长度不超过 maxLength 的前置单词
+func truncateWords(words [][]byte, sepLen int, maxLength int) [][]byte {
+	size := 0
+	for i, word := range words {
+		if i > 0 {
+			size += sepLen
+		}
+		size += len(word)
+		if size > maxLength {
+			if i == 0 {
+				return [][]byte{word[:maxLength]}
+			}
+			return words[:i]
+		}
+	}
+	return words
+}
@@ -0,0 +1,57 @@
+package xstrings
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		s    string
+		opts SlugOptions
+		want string
+	}{
+		{"", SlugOptions{}, ""},
+		{"  !!  ", SlugOptions{}, ""},
+		{"Hello World", SlugOptions{}, "hello-world"},
+		{"  Hello,   World!  ", SlugOptions{}, "hello-world"},
+		{"Crème Brûlée Recipes!", SlugOptions{}, "creme-brulee-recipes"},
+		{"Straße", SlugOptions{}, "strasse"},
+		{"STRAẞE", SlugOptions{}, "strasse"},
+		{"Øresund Bridge", SlugOptions{}, "oresund-bridge"},
+		{"Þorn and Æon", SlugOptions{}, "thorn-and-aeon"},
+		{"ÆON FLUX", SlugOptions{}, "aeon-flux"},
+		{"Œuvre complète", SlugOptions{}, "oeuvre-complete"},
+		{"Łódź, Kraków", SlugOptions{}, "lodz-krakow"},
+		{"Ștefan Țiriac", SlugOptions{}, "stefan-tiriac"},
+		{"Ärger über Öl", SlugOptions{}, "arger-uber-ol"},
+		{"用户 Guide", SlugOptions{}, "guide"},
+		{"3 × 4 ÷ 2", SlugOptions{}, "3-4-2"},
+		{"HTTPServer config", SlugOptions{}, "http-server-config"},
+		{"a.b/c?d=e&f", SlugOptions{}, "a-b-c-d-e-f"},
+		{"Hello World", SlugOptions{Separator: "_"}, "hello_world"},
+		{"Hello World", SlugOptions{Separator: "--"}, "hello--world"},
+		// 最大长度
+		{"The quick brown fox", SlugOptions{MaxLength: 15}, "the-quick-brown"},
+		{"The quick brown fox", SlugOptions{MaxLength: 14}, "the-quick"},
+		{"The quick brown fox", SlugOptions{MaxLength: 19}, "the-quick-brown-fox"},
+		{"The quick brown fox", SlugOptions{MaxLength: 100}, "the-quick-brown-fox"},
+		{"Supercalifragilistic word", SlugOptions{MaxLength: 5}, "super"},
+		{"The quick brown fox", SlugOptions{Separator: "__", MaxLength: 12}, "the__quick"},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.s, tt.opts); got != tt.want {
+			t.Errorf("Slugify(%q, %+v) = %q, want %q", tt.s, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifyLatinTable(t *testing.T) {
+	// 转写表中的字母均应转写为非空的 ASCII 字母
+	for r := rune(0xC0); r < 0x180; r++ {
+		if r == '×' || r == '÷' {
+			continue
+		}
+		got := Slugify(string(r), SlugOptions{})
+		if got == "" || !IsLower(got) || len(got) > 2 {
+			t.Errorf("Slugify(%q) = %q", r, got)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package xstrings
+
+// 本文件内是大小写转换相关的 SWAR 辅助函数，通用部分见 internal/swar
+
+import (
+	"encoding/binary"
+	"github.com/heyuuu/gophp-utils/internal/swar"
+	"math/bits"
+)
+
+// swarLowerMask 返回 x 中小写字母的字节掩码
+func swarLowerMask(x uint64) uint64 {
+	return swar.RangeMask(x, 'a', 'z')
+}
+
+// swarUpperMask 返回 x 中大写字母的字节掩码
+func swarUpperMask(x uint64) uint64 {
+	return swar.RangeMask(x, 'A', 'Z')
+}
+
+// swarToLower 将 x 中的大写字母转为小写，0x80 >> 2 即大小写字母的差值 0x20
+func swarToLower(x uint64) uint64 {
+	return x | swarUpperMask(x)>>2
+}
+
+// swarToUpper 将 x 中的小写字母转为大写
+func swarToUpper(x uint64) uint64 {
+	return x &^ (swarLowerMask(x) >> 2)
+}
+
+// swarFirstDiff 返回两个不相等的 word 中首个不同字节的下标
+func swarFirstDiff(x, y uint64) int {
+	return bits.TrailingZeros64(x^y) / 8
+}
+
+// swarPut 以小端序将 x 写入 buf[i:i+8]
+func swarPut(buf []byte, i int, x uint64) {
+	binary.LittleEndian.PutUint64(buf[i:], x)
+}
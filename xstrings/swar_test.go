@@ -0,0 +1,249 @@
+package xstrings
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// 以下为逐字节查表的参考实现，用于校验 SWAR 实现的正确性及性能对比
+
+func tableToUpper(s string) string {
+	var buf []byte
+	for i, c := range []byte(s) {
+		rc := tableUpper[c]
+		if rc != c {
+			if buf == nil {
+				buf = []byte(s)
+			}
+			buf[i] = rc
+		}
+	}
+	if buf == nil {
+		return s
+	}
+	return string(buf)
+}
+
+func tableToLower(s string) string {
+	var buf []byte
+	for i, c := range []byte(s) {
+		rc := tableLower[c]
+		if rc != c {
+			if buf == nil {
+				buf = []byte(s)
+			}
+			buf[i] = rc
+		}
+	}
+	if buf == nil {
+		return s
+	}
+	return string(buf)
+}
+
+func tableIsUpper(s string) bool {
+	for _, c := range []byte(s) {
+		if tableUpper[c] != c {
+			return false
+		}
+	}
+	return true
+}
+
+func tableIsLower(s string) bool {
+	for _, c := range []byte(s) {
+		if tableLower[c] != c {
+			return false
+		}
+	}
+	return true
+}
+
+func tableCompareFold(s1 string, s2 string) int {
+	l := min(len(s1), len(s2))
+	for i := 0; i < l; i++ {
+		c1, c2 := tableLower[s1[i]], tableLower[s2[i]]
+		if c1 == c2 {
+			continue
+		} else if c1 < c2 {
+			return -1
+		} else {
+			return 1
+		}
+	}
+	if l < len(s1) {
+		return 1
+	}
+	if l < len(s2) {
+		return -1
+	}
+	return 0
+}
+
+var tableUpper, tableLower [256]byte
+
+func init() {
+	for i := 0; i < 256; i++ {
+		tableUpper[i], tableLower[i] = byte(i), byte(i)
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		tableUpper[c] = byte(c - 'a' + 'A')
+	}
+	for c := 'A'; c <= 'Z'; c++ {
+		tableLower[c] = byte(c - 'A' + 'a')
+	}
+}
+
+// checkCaseEquivalence 校验单个字符串在各函数上与参考实现结果一致
+func checkCaseEquivalence(t *testing.T, s string) {
+	t.Helper()
+	if got, want := ToUpper(s), tableToUpper(s); got != want {
+		t.Fatalf("ToUpper(%s) = %s, want %s", strconv.Quote(s), strconv.Quote(got), strconv.Quote(want))
+	}
+	if got, want := ToLower(s), tableToLower(s); got != want {
+		t.Fatalf("ToLower(%s) = %s, want %s", strconv.Quote(s), strconv.Quote(got), strconv.Quote(want))
+	}
+	if got, want := IsUpper(s), tableIsUpper(s); got != want {
+		t.Fatalf("IsUpper(%s) = %v, want %v", strconv.Quote(s), got, want)
+	}
+	if got, want := IsLower(s), tableIsLower(s); got != want {
+		t.Fatalf("IsLower(%s) = %v, want %v", strconv.Quote(s), got, want)
+	}
+}
+
+// TestSwarCaseExhaustive 对每个字节值、每个长度(覆盖整 word 与尾部)、每个位置进行校验
+func TestSwarCaseExhaustive(t *testing.T) {
+	fillers := []byte{'a', 'Z', '0', 0x80, 0xff}
+	for n := 1; n <= 24; n++ {
+		for c := 0; c < 256; c++ {
+			checkCaseEquivalence(t, strings.Repeat(string([]byte{byte(c)}), n))
+			for _, filler := range fillers {
+				buf := []byte(strings.Repeat(string([]byte{filler}), n))
+				for pos := 0; pos < n; pos++ {
+					buf[pos] = byte(c)
+					checkCaseEquivalence(t, string(buf))
+					buf[pos] = filler
+				}
+			}
+		}
+	}
+}
+
+// TestSwarCompareFoldExhaustive 对所有字节对、word 内各位置及尾部位置进行校验
+func TestSwarCompareFoldExhaustive(t *testing.T) {
+	const prefix = "Mixed-Case_0123"
+	for _, pos := range []int{0, 3, 7, 8, 12, 15} {
+		for c1 := 0; c1 < 256; c1++ {
+			for c2 := 0; c2 < 256; c2++ {
+				b1 := []byte(prefix + "xY")
+				b2 := []byte(strings.ToUpper(prefix) + "Xy")
+				b1[pos], b2[pos] = byte(c1), byte(c2)
+				s1, s2 := string(b1), string(b2)
+				if got, want := CompareFold(s1, s2), tableCompareFold(s1, s2); got != want {
+					t.Fatalf("CompareFold(%s, %s) = %v, want %v", strconv.Quote(s1), strconv.Quote(s2), got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSwarRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	alphabet := []byte("aAzZ@[`{09 \x7f\x80\xc0\xe0\xff")
+	for i := 0; i < 100000; i++ {
+		n := r.Intn(40)
+		b1, b2 := make([]byte, n), make([]byte, r.Intn(40))
+		for j := range b1 {
+			b1[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		for j := range b2 {
+			b2[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		checkCaseEquivalence(t, string(b1))
+		if got, want := CompareFold(string(b1), string(b2)), tableCompareFold(string(b1), string(b2)); got != want {
+			t.Fatalf("CompareFold(%q, %q) = %v, want %v", b1, b2, got, want)
+		}
+	}
+}
+
+var benchmarkCaseInputs = []struct {
+	name string
+	s    string
+}{
+	{"short", "Hello"},
+	{"lower 1KB", strings.Repeat("lorem ipsum dolor sit amet ", 40)},
+	{"upper 1KB", strings.Repeat("LOREM IPSUM DOLOR SIT AMET ", 40)},
+	{"mixed 1KB", strings.Repeat("Lorem Ipsum Dolor Sit Amet ", 40)},
+	{"cjk 1KB", strings.Repeat("用户名Name", 80)},
+}
+
+func Benchmark_ToUpper(b *testing.B) {
+	for _, bm := range benchmarkCaseInputs {
+		b.Run("swar "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				ToUpper(bm.s)
+			}
+		})
+		b.Run("table "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				tableToUpper(bm.s)
+			}
+		})
+	}
+}
+
+func Benchmark_ToLower(b *testing.B) {
+	for _, bm := range benchmarkCaseInputs {
+		b.Run("swar "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				ToLower(bm.s)
+			}
+		})
+		b.Run("table "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				tableToLower(bm.s)
+			}
+		})
+	}
+}
+
+func Benchmark_IsLower(b *testing.B) {
+	for _, bm := range benchmarkCaseInputs {
+		b.Run("swar "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				IsLower(bm.s)
+			}
+		})
+		b.Run("table "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				tableIsLower(bm.s)
+			}
+		})
+	}
+}
+
+func Benchmark_CompareFold(b *testing.B) {
+	for _, bm := range benchmarkCaseInputs {
+		other := tableToUpper(bm.s)
+		b.Run("swar "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				CompareFold(bm.s, other)
+			}
+		})
+		b.Run("table "+bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.s)))
+			for i := 0; i < b.N; i++ {
+				tableCompareFold(bm.s, other)
+			}
+		})
+	}
+}
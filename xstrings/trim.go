@@ -0,0 +1,123 @@
+package xstrings
+
+// 本文件内是与 PHP trim()/ltrim()/rtrim()、strspn()/strcspn() 行为一致的函数，只按字节处理
+
+import (
+	"github.com/heyuuu/gophp-utils/ascii"
+)
+
+// TrimCharSet PHP trim() 系列函数的默认去除字符 " \t\n\r\0\x0B"
+// 注意与 ascii.IsSpace 不同: 包含 '\0'，不包含 '\f'
+var TrimCharSet = ascii.Set{0x0000000100002e01, 0, 0, 0}
+
+// trimSet 解析 charlist 参数，省略时使用默认字符集，多个 charlist 取并集
+// 与 PHP 一致，不合法的范围只会被当作普通字符处理；需要检查错误时可使用 ascii.ParseCharlist
+func trimSet(charlist []string) ascii.Set {
+	if len(charlist) == 0 {
+		return TrimCharSet
+	}
+	var set ascii.Set
+	for _, chars := range charlist {
+		s, _ := ascii.ParseCharlist(chars)
+		set = set.Union(s)
+	}
+	return set
+}
+
+// Trim 去除首尾字符，对应 PHP 函数 trim()
+// charlist 省略时去除 TrimCharSet 中的字符，否则按 PHP 字符列表解析(支持 "a..z" 范围语法)
+func Trim(s string, charlist ...string) string {
+	return TrimSet(s, trimSet(charlist))
+}
+
+// TrimLeft 去除开头字符，对应 PHP 函数 ltrim()，charlist 规则同 Trim
+func TrimLeft(s string, charlist ...string) string {
+	return TrimLeftSet(s, trimSet(charlist))
+}
+
+// TrimRight 去除末尾字符，对应 PHP 函数 rtrim()，charlist 规则同 Trim
+func TrimRight(s string, charlist ...string) string {
+	return TrimRightSet(s, trimSet(charlist))
+}
+
+// TrimSet 去除首尾属于 set 的字节
+func TrimSet(s string, set ascii.Set) string {
+	return TrimRightSet(TrimLeftSet(s, set), set)
+}
+
+// TrimLeftSet 去除开头属于 set 的字节
+func TrimLeftSet(s string, set ascii.Set) string {
+	i := 0
+	for i < len(s) && set.Contains(s[i]) {
+		i++
+	}
+	return s[i:]
+}
+
+// TrimRightSet 去除末尾属于 set 的字节
+func TrimRightSet(s string, set ascii.Set) string {
+	i := len(s)
+	for i > 0 && set.Contains(s[i-1]) {
+		i--
+	}
+	return s[:i]
+}
+
+// Span 返回 s 开头全部由 chars 中字节组成的最长子串长度，对应 PHP 函数 strspn()
+// 注意: 与 PHP 一致，chars 不支持 "a..z" 范围语法
+func Span(s string, chars string) int {
+	return span(s, ascii.NewSet(chars), true)
+}
+
+// CSpan 返回 s 开头全部不含 chars 中字节的最长子串长度，对应 PHP 函数 strcspn()
+func CSpan(s string, chars string) int {
+	return span(s, ascii.NewSet(chars), false)
+}
+
+// SpanAt 同 Span，但只检查 offset 和 length 指定的子串，对应 PHP 函数 strspn($string, $characters, $offset, $length)
+// - offset 为负数时从末尾倒数，超出开头时按 0 处理；offset 大于字符串长度时返回 0
+// - length 为负数时表示从子串末尾去掉的长度；length 超出剩余长度时按剩余长度处理(传入 len(s) 等价于 PHP 中省略 length)
+func SpanAt(s string, chars string, offset int, length int) int {
+	sub, ok := spanSubstr(s, offset, length)
+	if !ok {
+		return 0
+	}
+	return span(sub, ascii.NewSet(chars), true)
+}
+
+// CSpanAt 同 CSpan，但只检查 offset 和 length 指定的子串，对应 PHP 函数 strcspn($string, $characters, $offset, $length)
+// offset 和 length 规则同 SpanAt
+func CSpanAt(s string, chars string, offset int, length int) int {
+	sub, ok := spanSubstr(s, offset, length)
+	if !ok {
+		return 0
+	}
+	return span(sub, ascii.NewSet(chars), false)
+}
+
+// spanSubstr 按 PHP strspn()/strcspn() 的 offset/length 规则截取子串，offset 越界时返回 false
+func spanSubstr(s string, offset int, length int) (string, bool) {
+	if offset < 0 {
+		offset = max(offset+len(s), 0)
+	} else if offset > len(s) {
+		return "", false
+	}
+
+	remaining := len(s) - offset
+	if length < 0 {
+		length = max(length+remaining, 0)
+	} else if length > remaining {
+		length = remaining
+	}
+	return s[offset : offset+length], true
+}
+
+// span 返回 s 开头字节是否属于 set 均为 in 的最长前缀长度
+func span(s string, set ascii.Set, in bool) int {
+	for i := 0; i < len(s); i++ {
+		if set.Contains(s[i]) != in {
+			return i
+		}
+	}
+	return len(s)
+}
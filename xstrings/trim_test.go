@@ -0,0 +1,99 @@
+package xstrings
+
+import "testing"
+
+func TestTrim(t *testing.T) {
+	tests := []struct {
+		s        string
+		charlist []string
+		want     string
+		wantL    string
+		wantR    string
+	}{
+		{"", nil, "", "", ""},
+		{"  abc  ", nil, "abc", "abc  ", "  abc"},
+		{"\x00\t\n\x0b\r abc \r\x0b\n\t\x00", nil, "abc", "abc \r\x0b\n\t\x00", "\x00\t\n\x0b\r abc"},
+		{"\fabc\f", nil, "\fabc\f", "\fabc\f", "\fabc\f"},
+		{" \t\n", nil, "", "", ""},
+		{"xxabcxx", []string{"x"}, "abc", "abcxx", "xxabc"},
+		{"abcHELLOxyz", []string{"a..z"}, "HELLO", "HELLOxyz", "abcHELLO"},
+		{"123abc456", []string{"0..9"}, "abc", "abc456", "123abc"},
+		{"-_abc_-", []string{"-", "_"}, "abc", "abc_-", "-_abc"},
+		{"  abc  ", []string{""}, "  abc  ", "  abc  ", "  abc  "},
+		// 不合法的范围只作为普通字符处理
+		{"z.abc.z", []string{"z..a"}, "bc", "bc.z", "z.abc"},
+		{"..abc..", []string{"..c"}, "ab", "abc..", "..ab"},
+	}
+	for _, tt := range tests {
+		if got := Trim(tt.s, tt.charlist...); got != tt.want {
+			t.Errorf("Trim(%q, %q) = %q, want %q", tt.s, tt.charlist, got, tt.want)
+		}
+		if got := TrimLeft(tt.s, tt.charlist...); got != tt.wantL {
+			t.Errorf("TrimLeft(%q, %q) = %q, want %q", tt.s, tt.charlist, got, tt.wantL)
+		}
+		if got := TrimRight(tt.s, tt.charlist...); got != tt.wantR {
+			t.Errorf("TrimRight(%q, %q) = %q, want %q", tt.s, tt.charlist, got, tt.wantR)
+		}
+	}
+}
+
+func TestSpan(t *testing.T) {
+	tests := []struct {
+		s         string
+		chars     string
+		wantSpan  int
+		wantCSpan int
+	}{
+		{"", "abc", 0, 0},
+		{"abc", "", 0, 3},
+		{"42 is the answer", "1234567890", 2, 0},
+		{"hello world", " ", 0, 5},
+		{"aaab", "a", 3, 0},
+		{"abcd", "dcba", 4, 0},
+		{"a..z", "a..z", 4, 0},
+		{"b", "a..z", 0, 1},
+		{"a\x00b", "\x00", 0, 1},
+	}
+	for _, tt := range tests {
+		if got := Span(tt.s, tt.chars); got != tt.wantSpan {
+			t.Errorf("Span(%q, %q) = %d, want %d", tt.s, tt.chars, got, tt.wantSpan)
+		}
+		if got := CSpan(tt.s, tt.chars); got != tt.wantCSpan {
+			t.Errorf("CSpan(%q, %q) = %d, want %d", tt.s, tt.chars, got, tt.wantCSpan)
+		}
+	}
+}
+
+func TestSpanAt(t *testing.T) {
+	// 期望值与 PHP 8 的 strspn()/strcspn() 结果一致
+	tests := []struct {
+		s         string
+		chars     string
+		offset    int
+		length    int
+		wantSpan  int
+		wantCSpan int
+	}{
+		{"foo", "o", 1, 2, 2, 0},
+		{"foo", "o", 1, 1, 1, 0},
+		{"foo", "o", -1, 3, 1, 0},
+		{"foo", "o", -10, 3, 0, 1},
+		{"foo", "o", 3, 3, 0, 0},
+		{"foo", "o", 4, 3, 0, 0},
+		{"foo", "o", 0, -1, 0, 1},
+		{"foo", "o", 1, -1, 1, 0},
+		{"foo", "o", 1, -5, 0, 0},
+		{"abcd", "cd", 0, 100, 0, 2},
+		{"abcdhello", "l", -5, 4, 0, 2},
+		{"abcdhello", "l", -5, -2, 0, 2},
+		{"hello", "l", 1, 5, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := SpanAt(tt.s, tt.chars, tt.offset, tt.length); got != tt.wantSpan {
+			t.Errorf("SpanAt(%q, %q, %d, %d) = %d, want %d", tt.s, tt.chars, tt.offset, tt.length, got, tt.wantSpan)
+		}
+		if got := CSpanAt(tt.s, tt.chars, tt.offset, tt.length); got != tt.wantCSpan {
+			t.Errorf("CSpanAt(%q, %q, %d, %d) = %d, want %d", tt.s, tt.chars, tt.offset, tt.length, got, tt.wantCSpan)
+		}
+	}
+}
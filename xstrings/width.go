@@ -0,0 +1,72 @@
+package xstrings
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRanges 东亚宽字符(East Asian Wide / Fullwidth)的常见区间，终端中占两列
+var wideRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1}, // 谚文字母
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1}, // CJK 部首、标点
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1}, // 平假名、片假名、注音、CJK 兼容字符
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1}, // CJK 扩展 A
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1}, // CJK 统一汉字
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1}, // 彝文
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1}, // 谚文音节
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1}, // CJK 兼容汉字
+		{Lo: 0xfe30, Hi: 0xfe4f, Stride: 1}, // CJK 兼容标点
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1}, // 全角字符
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1}, // 全角符号
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1}, // 表情符号
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1}, // 补充表情符号
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1}, // CJK 扩展 B-F
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1}, // CJK 扩展 G
+	},
+}
+
+// RuneWidth 返回字符在等宽终端中的显示宽度
+// 控制字符与组合字符宽度为 0，东亚宽字符宽度为 2，其他字符宽度为 1
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7f:
+		return 0
+	case r < 0x7f:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.Is(wideRanges, r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// DisplayWidth 返回字符串在等宽终端中的显示宽度，用于 CJK 等宽字符对齐
+// 非法 UTF-8 字节按宽度 1 计算
+func DisplayWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			// ASCII 快速路径
+			if c >= 0x20 && c != 0x7f {
+				width++
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			width++
+		} else {
+			width += RuneWidth(r)
+		}
+		i += size
+	}
+	return width
+}
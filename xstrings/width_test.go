@@ -0,0 +1,28 @@
+package xstrings
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want int
+	}{
+		{"", "", 0},
+		{"", "hello", 5},
+		{"", "中文", 4},
+		{"", "用户のID", 8},
+		{"", "ｆｕｌｌ", 8},
+		{"", "한글", 4},
+		{"", "é", 1},
+		{"", "a\tb", 2},
+		{"", "\xff", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.arg); got != tt.want {
+				t.Errorf("DisplayWidth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
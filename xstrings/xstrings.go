@@ -5,13 +5,9 @@ import (
 	"strings"
 )
 
+// IsBlank 判断是否为空或只包含 ASCII 空白字符(" \t\n\v\f\r")
 func IsBlank(s string) bool {
-	for _, r := range s {
-		if !ascii.IsSpace(r) {
-			return false
-		}
-	}
-	return true
+	return ascii.IndexNonSpace(s) < 0
 }
 
 func PadLeft(s string, size int, pad byte) string {
@@ -21,7 +17,7 @@ func PadLeft(s string, size int, pad byte) string {
 
 	buf := make([]byte, size)
 	padSize := size - len(s)
-	copy(buf[:padSize], s)
+	copy(buf[padSize:], s)
 	for i := 0; i < padSize; i++ {
 		buf[i] = pad
 	}
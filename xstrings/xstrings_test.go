@@ -0,0 +1,43 @@
+package xstrings
+
+import "testing"
+
+func TestPadLeft(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		size int
+		want string
+	}{
+		{"", "", 3, "000"},
+		{"", "12", 5, "00012"},
+		{"", "12345", 3, "12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PadLeft(tt.arg, tt.size, '0'); got != tt.want {
+				t.Errorf("PadLeft() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		size int
+		want string
+	}{
+		{"", "", 3, "000"},
+		{"", "12", 5, "12000"},
+		{"", "12345", 3, "12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PadRight(tt.arg, tt.size, '0'); got != tt.want {
+				t.Errorf("PadRight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}